@@ -4,8 +4,13 @@ import (
 	"context"
 	"io"
 
+	"google.golang.org/protobuf/reflect/protoreflect"
+
 	"github.com/Olian04/form-from-schema/lib"
+	"github.com/Olian04/form-from-schema/lib/schemas/gostruct"
 	"github.com/Olian04/form-from-schema/lib/schemas/jsonschema"
+	"github.com/Olian04/form-from-schema/lib/schemas/openapi"
+	"github.com/Olian04/form-from-schema/lib/schemas/protobuf"
 	"github.com/Olian04/form-from-schema/lib/targets/html"
 )
 
@@ -23,6 +28,56 @@ func FromJsonSchema(schema []byte) (*lib.Form, error) {
 	return form, nil
 }
 
+// FromYamlSchema parses a JSON Schema written in YAML, or in JSON with comments, and
+// converts it to a Form struct. Fields follow the order of the YAML document.
+// The Form struct is NOT validated and should be validated before use by the caller
+func FromYamlSchema(schema []byte) (*lib.Form, error) {
+	yamlSchema, err := jsonschema.ParseYAML(schema)
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.ConvertSchemaToForm(yamlSchema)
+}
+
+// FromStruct converts a Go struct, or a pointer to one, to a Form struct, reading its
+// json, form, validate and label tags. Non-zero fields of v become defaults.
+// The Form struct is NOT validated and should be validated before use by the caller
+func FromStruct(v any) (*lib.Form, error) {
+	return gostruct.ConvertStructToForm(v)
+}
+
+// FromOpenAPI parses an OpenAPI 3.0 or 3.1 document and converts the operation with the
+// given operationId to a Form struct
+// The Form struct is NOT validated and should be validated before use by the caller
+func FromOpenAPI(spec []byte, operationID string) (*lib.Form, error) {
+	doc, err := openapi.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	return openapi.ConvertOperationToForm(doc, operationID)
+}
+
+// FromProtoMessage converts a Protocol Buffers message descriptor to a Form struct
+// The Form struct is NOT validated and should be validated before use by the caller
+func FromProtoMessage(desc protoreflect.MessageDescriptor) (*lib.Form, error) {
+	return protobuf.ConvertMessageToForm(desc)
+}
+
+// Convert converts a schema with the schema source registered under a name or media type,
+// such as "jsonschema", "yaml", "openapi", "protobuf" or "gostruct". Formats holding several
+// forms convert the one selected by name: an OpenAPI operationId, the full name of a
+// protobuf message or the name a struct was registered under with gostruct.Register.
+// The Form struct is NOT validated and should be validated before use by the caller
+func Convert(source string, schema []byte, name string) (*lib.Form, error) {
+	return lib.DefaultRegistry.Convert(source, schema, name)
+}
+
+// Render renders a form with the target registered under a name or media type, such as
+// "html" or "json", and writes it to the provided writer
+func Render(ctx context.Context, target string, form *lib.Form, w io.Writer) error {
+	return lib.DefaultRegistry.Render(ctx, target, form, w)
+}
+
 // ToHtml converts a Form struct to HTML and writes it to the provided writer
 func ToHtml(ctx context.Context, form *lib.Form, w io.Writer) error {
 	return html.ConvertFormToHtml(ctx, form, w)
@@ -0,0 +1,94 @@
+package formfromschema
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Olian04/form-from-schema/lib/schemas/gostruct"
+)
+
+type apiSignup struct {
+	Email string `json:"email" validate:"required,email"`
+	Plan  string `json:"plan,omitempty"`
+}
+
+func TestConvert(t *testing.T) {
+	if err := gostruct.Register("apiSignup", apiSignup{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		source     string
+		schema     string
+		formName   string
+		wantFields []string
+		errMsg     string
+	}{
+		{name: "jsonschema", source: "jsonschema", schema: handlerSchema, wantFields: []string{"email", "age", "tags"}},
+		{name: "yaml", source: "yaml", schema: "properties:\n  email: {type: string}\n", wantFields: []string{"email"}},
+		{
+			name:       "openapi by media type",
+			source:     "application/vnd.oai.openapi+json",
+			schema:     `{"openapi": "3.0.3", "paths": {"/pets": {"get": {"operationId": "listPets", "parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer"}}]}}}}`,
+			formName:   "listPets",
+			wantFields: []string{"limit"},
+		},
+		{name: "gostruct", source: "gostruct", schema: `{"plan": "pro"}`, formName: "apiSignup", wantFields: []string{"email", "plan"}},
+		{name: "openapi without operation", source: "openapi", schema: `{"openapi": "3.1.0"}`, errMsg: "an operationId is required"},
+		{name: "unregistered struct", source: "gostruct", formName: "apiLogin", errMsg: `struct "apiLogin" is not registered`},
+		{name: "unknown source", source: "graphql", errMsg: `unknown schema source "graphql" (must be one of: gostruct, jsonschema, openapi, protobuf, yaml)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form, err := Convert(tt.source, []byte(tt.schema), tt.formName)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("Convert() error = %v, want it to contain %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			var names []string
+			for _, field := range form.Fields {
+				names = append(names, field.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantFields, ",") {
+				t.Errorf("Convert() fields = %v, want %v", names, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	form, err := FromStruct(apiSignup{Plan: "pro"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "html", want: `<input type="text" id="plan" name="plan" value="pro">`},
+		{target: "text/html", want: `<form method="POST" action="" class="form">`},
+		{target: "json", want: `"default": "pro"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(context.Background(), tt.target, form, &buf); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("Render() = %s, want it to contain %s", buf.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+// Command form-from-schema converts a JSON Schema, or another schema format, into an HTML form.
+//
+// Usage:
+//
+//	form-from-schema [flags] [schema.json]
+//	form-from-schema serve [flags] schema.json
+//
+// The schema is read from the named file, or from stdin if no file or "-" is given. Files
+// named *.yaml, *.yml, *.jsonc or *.json5 are read as YAML, which also covers JSON with
+// comments and JSON5; -input selects the syntax explicitly, or any other registered schema
+// source, such as openapi with -name selecting the operation. The form is validated before
+// it is written, as HTML or as the intermediate lib.Form JSON, to stdout or the file named
+// by -o; -theme renders HTML as a standalone page. Errors are reported on stderr, qualified
+// by the schema file and the path of the offending field, with a non-zero exit code.
+//
+// The serve subcommand hosts a preview of the form on localhost instead. The preview is
+// rendered from the schema file on every request and reloads in the browser when the file
+// changes; submitting it shows the decoded JSON and any validation errors.
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	formfromschema "github.com/Olian04/form-from-schema"
+	"github.com/Olian04/form-from-schema/lib"
+	"github.com/Olian04/form-from-schema/lib/targets/html"
+)
+
+// Schema sources the syntax of a schema file selects
+const (
+	inputJSON = "jsonschema"
+	inputYAML = "yaml"
+)
+
+// Exit codes
+const (
+	exitOK    = 0
+	exitError = 1 // The schema could not be read, converted or validated, or the output written
+	exitUsage = 2 // The command line is invalid
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// options holds the command line flags
+type options struct {
+	input  string
+	name   string
+	action string
+	method string
+	format string
+	theme  string
+	output string
+}
+
+// run runs the command with the given arguments and standard streams, returning its exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "serve" {
+		return runServe(args[1:], stderr)
+	}
+
+	var opts options
+	flags := flag.NewFlagSet("form-from-schema", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&opts.input, "input", "", "schema `source`: jsonschema (or json), yaml for YAML and JSON with comments, openapi, protobuf, or a media type (default by file extension)")
+	flags.StringVar(&opts.name, "name", "", "`name` of the form to convert from sources holding several: an openapi operationId or a protobuf message")
+	flags.StringVar(&opts.action, "action", "", "`URL` the form submits to, instead of the one the schema gives")
+	flags.StringVar(&opts.method, "method", "", "HTTP `method` the form submits with, instead of the one the schema gives (POST for JSON Schema)")
+	flags.StringVar(&opts.format, "format", "html", "output `format`: html, json for the intermediate form structure, or a media type")
+	flags.StringVar(&opts.theme, "theme", "", "render a standalone HTML page styled by `theme` (none or default) instead of a form fragment")
+	flags.StringVar(&opts.output, "o", "", "write the output to `file` instead of stdout")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: form-from-schema [flags] [schema.json]")
+		fmt.Fprintln(stderr, "       form-from-schema serve [flags] schema.json")
+		fmt.Fprintln(stderr, "Converts a JSON Schema, or another schema format, read from the file or stdin, into an HTML form.")
+		fmt.Fprintln(stderr)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() > 1 {
+		fmt.Fprintf(stderr, "form-from-schema: expected at most one schema file, got %d\n", flags.NArg())
+		flags.Usage()
+		return exitUsage
+	}
+	if opts.input == "json" {
+		opts.input = inputJSON
+	}
+	if _, err := lib.DefaultRegistry.Source(opts.input); opts.input != "" && err != nil {
+		fmt.Fprintf(stderr, "form-from-schema: invalid input '%s' (must be one of: %s)\n", opts.input, strings.Join(lib.DefaultRegistry.SourceNames(), ", "))
+		return exitUsage
+	}
+	target, err := lib.DefaultRegistry.Target(opts.format)
+	if err != nil {
+		fmt.Fprintf(stderr, "form-from-schema: invalid format '%s' (must be one of: %s)\n", opts.format, strings.Join(lib.DefaultRegistry.TargetNames(), ", "))
+		return exitUsage
+	}
+
+	if err := html.ValidateTheme(html.Theme(opts.theme)); err != nil {
+		fmt.Fprintf(stderr, "form-from-schema: %v\n", err)
+		return exitUsage
+	}
+	if _, isHTML := target.(html.Target); opts.theme != "" && !isHTML {
+		fmt.Fprintf(stderr, "form-from-schema: -theme only applies to HTML output, not format '%s'\n", opts.format)
+		return exitUsage
+	}
+
+	source := flags.Arg(0)
+	if err := generate(source, opts, stdin, stdout); err != nil {
+		fmt.Fprintf(stderr, "form-from-schema: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// generate converts the schema read from source and writes the form in the requested format.
+// Output is only written once it is complete, so a failed run does not leave a partial file.
+func generate(source string, opts options, stdin io.Reader, stdout io.Writer) error {
+	schema, name, err := readSchema(source, stdin)
+	if err != nil {
+		return err
+	}
+
+	form, err := loadForm(name, schema, opts.input, opts.name, opts.action, opts.method)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := render(form, opts, &buf); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	if opts.output == "" {
+		_, err = stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(opts.output, buf.Bytes(), 0o644)
+}
+
+// readSchema reads the schema from the named file, or from stdin if name is empty or "-",
+// returning it with the name errors are reported under
+func readSchema(name string, stdin io.Reader) ([]byte, string, error) {
+	if name == "" || name == "-" {
+		schema, err := io.ReadAll(stdin)
+		return schema, "<stdin>", err
+	}
+	schema, err := os.ReadFile(name)
+	return schema, name, err
+}
+
+// loadForm converts a schema with the input schema source, or the source its name implies
+// if input is empty, into a form submitting to action with method, and validates it. An
+// empty action or method keeps the one the source gives the form. Sources holding several
+// forms convert the one selected by formName. Errors are qualified by the name of the schema.
+func loadForm(name string, schema []byte, input, formName, action, method string) (*lib.Form, error) {
+	if input == "" {
+		input = inputOf(name)
+	}
+
+	form, err := formfromschema.Convert(input, schema, formName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if action != "" {
+		form.Action = action
+	}
+	if method != "" {
+		form.Method = strings.ToUpper(method)
+	}
+	if err := form.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return form, nil
+}
+
+// inputOf returns the schema source of a schema file implied by its extension
+func inputOf(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".jsonc", ".json5":
+		return inputYAML
+	}
+	return inputJSON
+}
+
+// render writes the form in the requested format, as a page styled by the theme if it is HTML
+func render(form *lib.Form, opts options, w io.Writer) error {
+	target, err := lib.DefaultRegistry.Target(opts.format)
+	if err != nil {
+		return err
+	}
+	if _, ok := target.(html.Target); ok {
+		target = html.Target{Theme: html.Theme(opts.theme)}
+	}
+	return target.Render(context.Background(), form, w)
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+	"title": "Signup",
+	"properties": {
+		"email": {"type": "string", "format": "email"}
+	},
+	"required": ["email"]
+}`
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(testSchema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	yamlPath := filepath.Join(dir, "schema.yaml")
+	yamlSchema := "title: Signup\nproperties:\n  email:\n    type: string\n    format: email\n"
+	if err := os.WriteFile(yamlPath, []byte(yamlSchema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	openapiPath := filepath.Join(dir, "openapi.json")
+	openapiSpec := `{"openapi": "3.1.0", "paths": {"/pets/{id}": {"put": {"operationId": "updatePet",
+		"parameters": [{"name": "id", "in": "path", "schema": {"type": "integer"}}]}}}}`
+	if err := os.WriteFile(openapiPath, []byte(openapiSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	invalidPath := filepath.Join(dir, "invalid.json")
+	invalid := `{"properties": {"name": {"type": "string", "minLength": 5, "maxLength": 2}}}`
+	if err := os.WriteFile(invalidPath, []byte(invalid), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		args         []string
+		stdin        string
+		wantCode     int
+		wantStdout   []string
+		wantStderr   string
+		wantNoStdout bool
+	}{
+		{
+			name:       "schema file to html",
+			args:       []string{"-action", "/signup", "-method", "put", schemaPath},
+			wantCode:   exitOK,
+			wantStdout: []string{`<form method="POST" action="/signup" class="form"><input type="hidden" name="_method" value="PUT">`, `<input type="email" id="email" name="email" value="" required>`},
+		},
+		{
+			name:       "stdin to json",
+			args:       []string{"-format", "json", "-"},
+			stdin:      testSchema,
+			wantCode:   exitOK,
+			wantStdout: []string{`"title": "Signup"`, `"method": "POST"`},
+		},
+		{
+			name:       "yaml file",
+			args:       []string{yamlPath},
+			wantCode:   exitOK,
+			wantStdout: []string{`<input type="email" id="email" name="email" value="">`},
+		},
+		{
+			name:       "yaml stdin",
+			args:       []string{"-input", "yaml"},
+			stdin:      "title: Signup\nproperties:\n  email: {type: string, minLength: short}\n",
+			wantCode:   exitError,
+			wantStderr: "form-from-schema: <stdin>: line 3, column 36: invalid minLength: cannot use string as int",
+		},
+		{
+			name:       "openapi operation",
+			args:       []string{"-input", "openapi", "-name", "updatePet", openapiPath},
+			wantCode:   exitOK,
+			wantStdout: []string{`<form method="POST" action="/pets/{id}" class="form" data-action="/pets/{id}"><input type="hidden" name="_method" value="PUT">`, `<input type="number" id="id" name="id" value="" required>`},
+		},
+		{
+			name:       "action overriding the operation's",
+			args:       []string{"-input", "openapi", "-name", "updatePet", "-action", "/pets/7", openapiPath},
+			wantCode:   exitOK,
+			wantStdout: []string{`<form method="POST" action="/pets/7" class="form"><input type="hidden" name="_method" value="PUT">`},
+		},
+		{
+			name:       "source and format by media type",
+			args:       []string{"-input", "application/schema+json", "-format", "application/json"},
+			stdin:      testSchema,
+			wantCode:   exitOK,
+			wantStdout: []string{`"title": "Signup"`},
+		},
+		{
+			name:       "name of a single form source",
+			args:       []string{"-name", "Signup", schemaPath},
+			wantCode:   exitError,
+			wantStderr: `a JSON Schema holds a single form, got name "Signup"`,
+		},
+		{
+			name:       "themed page",
+			args:       []string{"-theme", "default"},
+			stdin:      testSchema,
+			wantCode:   exitOK,
+			wantStdout: []string{`<!doctype html>`, `<title>Signup</title><style>`},
+		},
+		{
+			name:         "invalid form",
+			args:         []string{invalidPath},
+			wantCode:     exitError,
+			wantStderr:   "form-from-schema: " + invalidPath + ": fields[0]: validation.minLength (5) cannot be greater than maxLength (2)",
+			wantNoStdout: true,
+		},
+		{
+			name:         "invalid json",
+			args:         []string{},
+			stdin:        `{"properties":`,
+			wantCode:     exitError,
+			wantStderr:   "form-from-schema: <stdin>: unexpected end of JSON input",
+			wantNoStdout: true,
+		},
+		{
+			name:       "missing file",
+			args:       []string{filepath.Join(dir, "missing.json")},
+			wantCode:   exitError,
+			wantStderr: "no such file or directory",
+		},
+		{
+			name:       "help keeps the source's method by default",
+			args:       []string{"-h"},
+			wantCode:   exitOK,
+			wantStderr: "instead of the one the schema gives (POST for JSON Schema)\n",
+		},
+		{
+			name:       "invalid method",
+			args:       []string{"-method", "FETCH"},
+			stdin:      testSchema,
+			wantCode:   exitError,
+			wantStderr: "invalid HTTP method: FETCH",
+		},
+		{
+			name:       "invalid format",
+			args:       []string{"-format", "xml", schemaPath},
+			wantCode:   exitUsage,
+			wantStderr: "invalid format 'xml'",
+		},
+		{
+			name:       "invalid input",
+			args:       []string{"-input", "toml", schemaPath},
+			wantCode:   exitUsage,
+			wantStderr: "invalid input 'toml'",
+		},
+		{
+			name:       "invalid theme",
+			args:       []string{"-theme", "dark", schemaPath},
+			wantCode:   exitUsage,
+			wantStderr: "invalid theme 'dark'",
+		},
+		{
+			name:       "theme with JSON output",
+			args:       []string{"-theme", "default", "-format", "json", schemaPath},
+			wantCode:   exitUsage,
+			wantStderr: "-theme only applies to HTML output, not format 'json'",
+		},
+		{
+			name:       "too many files",
+			args:       []string{schemaPath, schemaPath},
+			wantCode:   exitUsage,
+			wantStderr: "expected at most one schema file, got 2",
+		},
+		{
+			name:       "unknown flag",
+			args:       []string{"-colour"},
+			wantCode:   exitUsage,
+			wantStderr: "flag provided but not defined: -colour",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Fatalf("run() = %d, want %d. Stderr: %s", code, tt.wantCode, stderr.String())
+			}
+			for _, want := range tt.wantStdout {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("stdout does not contain %q. Stdout: %s", want, stdout.String())
+				}
+			}
+			if tt.wantNoStdout && stdout.Len() > 0 {
+				t.Errorf("stdout = %q, want nothing", stdout.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRun_OutputFile(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "form.json")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-format", "json", "-o", output}, strings.NewReader(testSchema), &stdout, &stderr); code != exitOK {
+		t.Fatalf("run() = %d, want %d. Stderr: %s", code, exitOK, stderr.String())
+	}
+	if stdout.Len() > 0 {
+		t.Errorf("stdout = %q, want nothing", stdout.String())
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("reading output: %v", err)
+	}
+	var form struct {
+		Title string `json:"title"`
+	}
+	if err := json.Unmarshal(data, &form); err != nil || form.Title != "Signup" {
+		t.Errorf("output = %s, want the Signup form as JSON", data)
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/Olian04/form-from-schema/lib"
+	"github.com/Olian04/form-from-schema/lib/targets/html"
+)
+
+// Preview server defaults
+const (
+	defaultAddr  = "localhost:8080"
+	pollInterval = 250 * time.Millisecond // How often the schema file is checked for changes
+)
+
+// runServe runs the serve subcommand, returning its exit code once the server is stopped
+func runServe(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("form-from-schema serve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	addr := flags.String("addr", defaultAddr, "`address` to listen on")
+	theme := flags.String("theme", string(html.ThemeDefault), "`theme` the preview is styled by (none or default)")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: form-from-schema serve [flags] schema.json")
+		fmt.Fprintln(stderr, "Hosts a preview of the form that reloads when the schema changes.")
+		fmt.Fprintln(stderr)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintf(stderr, "form-from-schema: serve expects exactly one schema file, got %d\n", flags.NArg())
+		flags.Usage()
+		return exitUsage
+	}
+	if err := html.ValidateTheme(html.Theme(*theme)); err != nil {
+		fmt.Fprintf(stderr, "form-from-schema: %v\n", err)
+		return exitUsage
+	}
+
+	preview := &previewServer{path: flags.Arg(0), theme: html.Theme(*theme), poll: pollInterval}
+	if _, err := os.Stat(preview.path); err != nil {
+		fmt.Fprintf(stderr, "form-from-schema: %v\n", err)
+		return exitError
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintf(stderr, "form-from-schema: %v\n", err)
+		return exitError
+	}
+
+	// Requests share a context canceled on interrupt, so open event streams end on shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	server := &http.Server{
+		Handler:     preview.handler(),
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+
+	fmt.Fprintf(stderr, "Previewing %s on http://%s (press Ctrl+C to stop)\n", preview.path, listener.Addr())
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(stderr, "form-from-schema: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// previewServer hosts the form of a schema file, loading the file anew for every request
+type previewServer struct {
+	path  string
+	theme html.Theme
+	poll  time.Duration
+}
+
+// preview holds what a preview page shows
+type preview struct {
+	name       string
+	theme      html.Theme
+	version    string    // Version of the schema file the page was rendered from
+	form       *lib.Form // The form, or nil if the schema could not be loaded
+	err        string    // Why the schema could not be loaded or the submission decoded
+	submission *submission
+}
+
+// submission holds a decoded submission and the errors found validating it
+type submission struct {
+	json   string
+	errors []submissionError
+}
+
+// submissionError is a validation error of a submission
+type submissionError struct {
+	path    string
+	message string
+}
+
+// handler returns the handler serving the preview page, its submissions and its reload events
+func (p *previewServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", p.show)
+	mux.HandleFunc("POST /{$}", p.submit)
+	mux.HandleFunc("GET /events", p.events)
+	return mux
+}
+
+// load loads the schema file into a preview, recording why it failed if it could not be
+// converted into a valid form
+func (p *previewServer) load() preview {
+	page := preview{name: p.path, theme: p.theme, version: p.version()}
+
+	schema, err := os.ReadFile(p.path)
+	if err != nil {
+		page.err = err.Error()
+		return page
+	}
+	form, err := loadForm(p.path, schema, "", "", "/", http.MethodPost)
+	if err != nil {
+		page.err = err.Error()
+		return page
+	}
+	page.form = form
+	return page
+}
+
+// show renders the preview page
+func (p *previewServer) show(w http.ResponseWriter, r *http.Request) {
+	p.render(w, r, http.StatusOK, p.load())
+}
+
+// submit decodes and validates a submission, rendering the preview page with the form
+// populated from it, the decoded JSON and the errors of values that cannot be decoded or
+// are invalid. Submissions made by the add and remove item buttons only edit the items of
+// the re-rendered form.
+func (p *previewServer) submit(w http.ResponseWriter, r *http.Request) {
+	page := p.load()
+	if page.form == nil {
+		p.render(w, r, http.StatusOK, page)
+		return
+	}
+	form := page.form
+
+	values, err := form.DecodeRequest(r)
+	decodeErrs, ok := err.(lib.FieldErrors)
+	if err != nil && !ok {
+		page.err = err.Error()
+		p.render(w, r, http.StatusBadRequest, page)
+		return
+	}
+
+	edited, ok, err := form.EditItems(r.Form)
+	if err != nil {
+		page.err = err.Error()
+		p.render(w, r, http.StatusBadRequest, page)
+		return
+	}
+	selected := form.DecodeVariants(r.Form)
+	if ok {
+		page.form = form.PopulateWithVariants(edited, nil, selected)
+		p.render(w, r, http.StatusOK, page)
+		return
+	}
+
+	errs := decodeErrs.Merge(form.ValidateValuesWithVariants(values, selected))
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	page.form = form.PopulateWithVariants(values, errs, selected)
+	page.submission = &submission{json: string(data), errors: sortedErrors(errs)}
+
+	status := http.StatusOK
+	if errs != nil {
+		status = http.StatusUnprocessableEntity
+	}
+	p.render(w, r, status, page)
+}
+
+// events streams a reload event once the schema file no longer has the version the page
+// listening was rendered from, then ends the stream
+func (p *previewServer) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	version := r.URL.Query().Get("version")
+	ticker := time.NewTicker(p.poll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if p.version() != version {
+				fmt.Fprint(w, "event: reload\ndata: \n\n")
+				flusher.Flush()
+				return
+			}
+		}
+	}
+}
+
+// version identifies the contents of the schema file by its size and modification time
+func (p *previewServer) version() string {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d", info.Size(), info.ModTime().UnixNano())
+}
+
+// render writes the preview page with the given status, or 500 if it cannot be rendered
+func (p *previewServer) render(w http.ResponseWriter, r *http.Request, status int, page preview) {
+	var body bytes.Buffer
+	if err := previewPage(page).Render(r.Context(), &body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(body.Bytes())
+}
+
+// sortedErrors lists validation errors ordered by path
+func sortedErrors(errs lib.FieldErrors) []submissionError {
+	list := make([]submissionError, 0, len(errs))
+	for path, message := range errs {
+		list = append(list, submissionError{path: path, message: message})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].path < list[j].path
+	})
+	return list
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Olian04/form-from-schema/lib/targets/html"
+)
+
+// newTestPreview writes schema to a temporary file and serves its preview
+func newTestPreview(t *testing.T, schema string) (*httptest.Server, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(path, []byte(schema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	preview := &previewServer{path: path, theme: html.ThemeDefault, poll: 10 * time.Millisecond}
+	server := httptest.NewServer(preview.handler())
+	t.Cleanup(server.Close)
+	return server, path
+}
+
+// readBody reads and closes a response body
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestPreviewServer(t *testing.T) {
+	server, _ := newTestPreview(t, `{
+		"title": "Signup",
+		"properties": {
+			"email": {"type": "string", "format": "email"},
+			"age": {"type": "integer", "minimum": 18},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		},
+		"required": ["email"]
+	}`)
+
+	tests := []struct {
+		name         string
+		method       string
+		values       url.Values
+		wantStatus   int
+		wantContains []string
+		notContains  []string
+	}{
+		{
+			name:       "show form",
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantContains: []string{
+				`<title>`,
+				`<style>`,
+				`<form method="POST" action="/" class="form"><h1>Signup</h1>`,
+				`new EventSource("/events?version="`,
+			},
+			notContains: []string{`class="submission"`},
+		},
+		{
+			name:       "invalid submission",
+			method:     http.MethodPost,
+			values:     url.Values{"age": {"3"}},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantContains: []string{
+				`value="3" aria-describedby="age-error" aria-invalid="true"`,
+				`<li><code>age</code>: Must be at least 18</li><li><code>email</code>: This field is required</li>`,
+				`<pre class="submission-json">{
+  &#34;age&#34;: 3
+}</pre>`,
+			},
+		},
+		{
+			name:       "undecodable submission",
+			method:     http.MethodPost,
+			values:     url.Values{"age": {"abc"}},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantContains: []string{
+				`value="abc" aria-describedby="age-error" aria-invalid="true"`,
+				`<li><code>age</code>: Must be a whole number</li><li><code>email</code>: This field is required</li>`,
+			},
+		},
+		{
+			name:       "valid submission",
+			method:     http.MethodPost,
+			values:     url.Values{"email": {"ada@example.com"}, "tags[0]": {"admin"}},
+			wantStatus: http.StatusOK,
+			wantContains: []string{
+				`<p>The submission is valid.</p>`,
+				`&#34;email&#34;: &#34;ada@example.com&#34;`,
+			},
+			notContains: []string{`class="submission-errors"`},
+		},
+		{
+			name:       "add item",
+			method:     http.MethodPost,
+			values:     url.Values{"tags[0]": {"admin"}, "_add-item": {"tags"}},
+			wantStatus: http.StatusOK,
+			wantContains: []string{
+				`name="tags[0]" value="admin"`,
+				`name="tags[1]" value=""`,
+			},
+			notContains: []string{`class="submission"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp *http.Response
+			var err error
+			if tt.method == http.MethodPost {
+				resp, err = http.PostForm(server.URL, tt.values)
+			} else {
+				resp, err = http.Get(server.URL)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			body := readBody(t, resp)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(body, want) {
+					t.Errorf("body does not contain %q. Body: %s", want, body)
+				}
+			}
+			for _, notWant := range tt.notContains {
+				if strings.Contains(body, notWant) {
+					t.Errorf("body contains unexpected %q. Body: %s", notWant, body)
+				}
+			}
+		})
+	}
+}
+
+func TestPreviewServer_InvalidSchema(t *testing.T) {
+	server, path := newTestPreview(t, `{"properties": {"name": {"type": "string", "minLength": 5, "maxLength": 2}}}`)
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := readBody(t, resp)
+
+	want := `<pre class="form-error" role="alert">` + path + `: fields[0]: validation.minLength (5) cannot be greater than maxLength (2)</pre>`
+	if !strings.Contains(body, want) {
+		t.Errorf("body does not contain %q. Body: %s", want, body)
+	}
+	if !strings.Contains(body, "EventSource") {
+		t.Errorf("body does not reload once the schema is fixed. Body: %s", body)
+	}
+}
+
+func TestPreviewServer_Events(t *testing.T) {
+	server, path := newTestPreview(t, `{"properties": {"name": {"type": "string"}}}`)
+	version := (&previewServer{path: path}).version()
+
+	resp, err := http.Get(server.URL + "/events?version=" + url.QueryEscape(version))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", got)
+	}
+
+	// Change the size as well, as modification times can be coarse
+	if err := os.WriteFile(path, []byte(`{"properties": {"email": {"type": "string"}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatalf("reading event: %v", err)
+	}
+	if line != "event: reload\n" {
+		t.Errorf("event = %q, want reload", line)
+	}
+}
+
+func TestRunServe_Usage(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStderr string
+	}{
+		{name: "no schema file", args: []string{"serve"}, wantCode: exitUsage, wantStderr: "serve expects exactly one schema file, got 0"},
+		{name: "invalid theme", args: []string{"serve", "-theme", "dark", "schema.json"}, wantCode: exitUsage, wantStderr: "invalid theme 'dark'"},
+		{name: "missing schema file", args: []string{"serve", filepath.Join(t.TempDir(), "missing.json")}, wantCode: exitError, wantStderr: "no such file or directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			if code := run(tt.args, strings.NewReader(""), &stdout, &stderr); code != tt.wantCode {
+				t.Fatalf("run() = %d, want %d. Stderr: %s", code, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package formfromschema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Olian04/form-from-schema/lib"
+	"github.com/Olian04/form-from-schema/lib/targets/html"
+)
+
+// SubmitFunc handles the decoded JSON of a valid submission. Returning lib.FieldErrors
+// shows the form again with those errors next to their fields.
+type SubmitFunc func(ctx context.Context, data json.RawMessage) error
+
+// submitErrorMessage is shown when a SubmitFunc fails with an error that is not tied to
+// fields, whose message is not meant for the user
+const submitErrorMessage = "The form could not be submitted, please try again"
+
+// FormHandler serves a form as an HTML page on GET, and handles its submissions on POST:
+// they are decoded and validated, shown again with their errors if invalid, and passed to
+// OnSubmit otherwise, redirecting to Redirect once handled. Forms with a method HTML forms
+// cannot be submitted with, such as PUT, are submitted with POST carrying their method in
+// lib.MethodParam, as the HTML target renders them. GET forms are submitted in the query
+// string, so a GET request with a query is handled as a submission.
+type FormHandler struct {
+	Form     *lib.Form
+	OnSubmit SubmitFunc
+	Redirect string     // URL redirected to after a successful submission, the form's own URL if empty
+	Theme    html.Theme // Theme the page is styled by
+}
+
+// NewHandler returns a handler for the form. The form is validated, and set to be submitted
+// with POST if it has no method.
+func NewHandler(form *lib.Form, onSubmit SubmitFunc) (*FormHandler, error) {
+	if form == nil {
+		return nil, fmt.Errorf("form cannot be nil")
+	}
+	if onSubmit == nil {
+		return nil, fmt.Errorf("onSubmit cannot be nil")
+	}
+
+	handled := *form
+	if handled.Method == "" {
+		handled.Method = http.MethodPost
+	}
+	handled.Method = strings.ToUpper(handled.Method)
+	if err := handled.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &FormHandler{Form: &handled, OnSubmit: onSubmit, Theme: html.ThemeDefault}, nil
+}
+
+// NewJsonSchemaHandler returns a handler for the form generated from a JSON Schema, like NewHandler
+func NewJsonSchemaHandler(schema []byte, onSubmit SubmitFunc) (*FormHandler, error) {
+	form, err := FromJsonSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	return NewHandler(form, onSubmit)
+}
+
+// NewSchemaHandler returns a handler for the form converted from a schema by the schema
+// source registered under a name or media type, like Convert and NewHandler
+func NewSchemaHandler(source string, schema []byte, name string, onSubmit SubmitFunc) (*FormHandler, error) {
+	form, err := Convert(source, schema, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewHandler(form, onSubmit)
+}
+
+// ServeHTTP implements http.Handler
+func (h *FormHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && h.Form.Method == http.MethodGet && r.URL.RawQuery != "":
+		h.submit(w, r)
+	case r.Method == http.MethodGet, r.Method == http.MethodHead:
+		h.render(w, r, http.StatusOK, h.Form)
+	case r.Method == http.MethodPost && h.Form.Method != http.MethodGet:
+		h.submit(w, r)
+	default:
+		allow := "GET, HEAD, POST"
+		if h.Form.Method == http.MethodGet {
+			allow = "GET, HEAD"
+		}
+		w.Header().Set("Allow", allow)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// submittedMethod returns the method a parsed request was submitted with, or stands in for
+// if it is a POST request naming another method in its lib.MethodParam parameter
+func submittedMethod(r *http.Request) string {
+	if method := r.PostForm.Get(lib.MethodParam); r.Method == http.MethodPost && method != "" {
+		return strings.ToUpper(method)
+	}
+	return r.Method
+}
+
+// submit handles a submission of the form
+func (h *FormHandler) submit(w http.ResponseWriter, r *http.Request) {
+	// Values that cannot be decoded are reported along with the invalid ones
+	values, err := h.Form.DecodeRequest(r)
+	decodeErrs, ok := err.(lib.FieldErrors)
+	if err != nil && !ok {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if method := submittedMethod(r); method != h.Form.Method {
+		http.Error(w, fmt.Sprintf("form must be submitted with %s, got %s", h.Form.Method, method), http.StatusBadRequest)
+		return
+	}
+
+	// The add and remove item buttons only edit the items of the re-rendered form
+	edited, ok, err := h.Form.EditItems(r.Form)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	selected := h.Form.DecodeVariants(r.Form)
+	if ok {
+		h.render(w, r, http.StatusOK, h.Form.PopulateWithVariants(edited, nil, selected))
+		return
+	}
+
+	if errs := decodeErrs.Merge(h.Form.ValidateValuesWithVariants(values, selected)); errs != nil {
+		h.render(w, r, http.StatusUnprocessableEntity, h.Form.PopulateWithVariants(values, errs, selected))
+		return
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := h.OnSubmit(r.Context(), data); err != nil {
+		var errs lib.FieldErrors
+		if errors.As(err, &errs) {
+			h.render(w, r, http.StatusUnprocessableEntity, h.Form.PopulateWithVariants(values, errs, selected))
+			return
+		}
+		h.render(w, r, http.StatusInternalServerError, h.Form.PopulateWithVariants(values, lib.FieldErrors{"": submitErrorMessage}, selected))
+		return
+	}
+
+	redirect := h.Redirect
+	if redirect == "" {
+		redirect = r.URL.RequestURI()
+		if r.Method == http.MethodGet {
+			// Without the submitted query, which would submit the form again
+			redirect = r.URL.Path
+		}
+	}
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+// render writes the form as an HTML page with the given status. The page is rendered in
+// full first, so a failure is answered with 500 rather than a truncated page.
+func (h *FormHandler) render(w http.ResponseWriter, r *http.Request, status int, form *lib.Form) {
+	var page bytes.Buffer
+	if err := html.ConvertFormToHtmlPage(r.Context(), form, h.Theme, &page); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(page.Bytes())
+}
@@ -0,0 +1,359 @@
+package formfromschema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+const handlerSchema = `{
+	"title": "Signup",
+	"properties": {
+		"email": {"type": "string", "format": "email"},
+		"age": {"type": "integer", "minimum": 18},
+		"tags": {"type": "array", "items": {"type": "string"}}
+	},
+	"required": ["email"]
+}`
+
+func TestNewHandler(t *testing.T) {
+	onSubmit := func(context.Context, json.RawMessage) error { return nil }
+	field := []lib.Field{{Name: "email", Type: lib.FieldTypeEmail}}
+
+	tests := []struct {
+		name       string
+		form       *lib.Form
+		onSubmit   SubmitFunc
+		wantMethod string
+		errMsg     string
+	}{
+		{name: "defaults to POST", form: &lib.Form{Fields: field}, onSubmit: onSubmit, wantMethod: http.MethodPost},
+		{name: "PUT form", form: &lib.Form{Method: "put", Fields: field}, onSubmit: onSubmit, wantMethod: http.MethodPut},
+		{name: "GET form", form: &lib.Form{Method: "GET", Fields: field}, onSubmit: onSubmit, wantMethod: http.MethodGet},
+		{name: "nil form", onSubmit: onSubmit, errMsg: "form cannot be nil"},
+		{name: "nil onSubmit", form: &lib.Form{Fields: field}, errMsg: "onSubmit cannot be nil"},
+		{name: "invalid form", form: &lib.Form{}, onSubmit: onSubmit, errMsg: "form must have at least one field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler(tt.form, tt.onSubmit)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("NewHandler() error = %v, want it to contain %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewHandler() error = %v", err)
+			}
+			if handler.Form.Method != tt.wantMethod || handler.Form == tt.form {
+				t.Errorf("Form.Method = %q, want %s set on a copy", handler.Form.Method, tt.wantMethod)
+			}
+		})
+	}
+}
+
+func TestFormHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		values       url.Values
+		submitErr    error
+		wantStatus   int
+		wantLocation string
+		wantData     string
+		wantContains []string
+	}{
+		{
+			name:         "render",
+			method:       http.MethodGet,
+			wantStatus:   http.StatusOK,
+			wantContains: []string{`<!doctype html>`, `<form method="POST" action="" class="form"><h1>Signup</h1>`},
+		},
+		{
+			name:         "invalid submission",
+			method:       http.MethodPost,
+			values:       url.Values{"age": {"3"}},
+			wantStatus:   http.StatusUnprocessableEntity,
+			wantContains: []string{`<span id="email-error" class="field-error">This field is required</span>`, `value="3"`},
+		},
+		{
+			name:       "undecodable submission",
+			method:     http.MethodPost,
+			values:     url.Values{"age": {"abc"}},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantContains: []string{
+				`<span id="age-error" class="field-error">Must be a whole number</span>`,
+				`<span id="email-error" class="field-error">This field is required</span>`,
+				`value="abc"`,
+			},
+		},
+		{
+			name:         "valid submission",
+			method:       http.MethodPost,
+			values:       url.Values{"email": {"ada@example.com"}, "age": {"36"}},
+			wantStatus:   http.StatusSeeOther,
+			wantLocation: "/signup?ref=home",
+			wantData:     `{"age":36,"email":"ada@example.com"}`,
+		},
+		{
+			name:         "field errors from onSubmit",
+			method:       http.MethodPost,
+			values:       url.Values{"email": {"ada@example.com"}},
+			submitErr:    lib.FieldErrors{"email": "Already registered"},
+			wantStatus:   http.StatusUnprocessableEntity,
+			wantData:     `{"email":"ada@example.com"}`,
+			wantContains: []string{`<span id="email-error" class="field-error">Already registered</span>`},
+		},
+		{
+			name:         "other error from onSubmit",
+			method:       http.MethodPost,
+			values:       url.Values{"email": {"ada@example.com"}},
+			submitErr:    errors.New("database is down"),
+			wantStatus:   http.StatusInternalServerError,
+			wantData:     `{"email":"ada@example.com"}`,
+			wantContains: []string{`<p class="form-error" role="alert">The form could not be submitted, please try again</p>`},
+		},
+		{
+			name:         "add item",
+			method:       http.MethodPost,
+			values:       url.Values{"tags[0]": {"a"}, lib.AddItemParam: {"tags"}},
+			wantStatus:   http.StatusOK,
+			wantContains: []string{`name="tags[0]" value="a"`, `name="tags[1]" value=""`},
+		},
+		{
+			name:       "submission standing in for another method",
+			method:     http.MethodPost,
+			values:     url.Values{"email": {"ada@example.com"}, lib.MethodParam: {"PUT"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unsupported method",
+			method:     http.MethodDelete,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var submitted string
+			handler, err := NewJsonSchemaHandler([]byte(handlerSchema), func(ctx context.Context, data json.RawMessage) error {
+				submitted = string(data)
+				return tt.submitErr
+			})
+			if err != nil {
+				t.Fatalf("NewJsonSchemaHandler() error = %v", err)
+			}
+
+			req := httptest.NewRequest(tt.method, "/signup?ref=home", strings.NewReader(tt.values.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d. Body: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if got := resp.Header.Get("Location"); got != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", got, tt.wantLocation)
+			}
+			if submitted != tt.wantData {
+				t.Errorf("submitted data = %s, want %s", submitted, tt.wantData)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(string(body), want) {
+					t.Errorf("body does not contain %q. Body: %s", want, body)
+				}
+			}
+		})
+	}
+}
+
+func TestFormHandler_Redirect(t *testing.T) {
+	handler, err := NewJsonSchemaHandler([]byte(handlerSchema), func(context.Context, json.RawMessage) error { return nil })
+	if err != nil {
+		t.Fatalf("NewJsonSchemaHandler() error = %v", err)
+	}
+	handler.Redirect = "/thanks"
+
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader("email=ada%40example.com"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/thanks" {
+		t.Errorf("response = %d to %q, want %d to /thanks", rec.Code, rec.Header().Get("Location"), http.StatusSeeOther)
+	}
+}
+
+func TestFormHandler_RenderError(t *testing.T) {
+	handler, err := NewJsonSchemaHandler([]byte(handlerSchema), func(context.Context, json.RawMessage) error { return nil })
+	if err != nil {
+		t.Fatalf("NewJsonSchemaHandler() error = %v", err)
+	}
+	handler.Theme = "neon"
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/signup", nil))
+
+	if rec.Code != http.StatusInternalServerError || strings.Contains(rec.Body.String(), "<form") {
+		t.Errorf("response = %d with body %q, want %d without a page", rec.Code, rec.Body, http.StatusInternalServerError)
+	}
+}
+
+func TestNewSchemaHandler(t *testing.T) {
+	onSubmit := func(context.Context, json.RawMessage) error { return nil }
+
+	handler, err := NewSchemaHandler("yaml", []byte("properties:\n  email: {type: string, format: email}\n"), "", onSubmit)
+	if err != nil {
+		t.Fatalf("NewSchemaHandler() error = %v", err)
+	}
+	if handler.Form.Fields[0].Type != lib.FieldTypeEmail {
+		t.Errorf("Form.Fields[0].Type = %q, want email", handler.Form.Fields[0].Type)
+	}
+
+	if _, err := NewSchemaHandler("toml", nil, "", onSubmit); err == nil || !strings.Contains(err.Error(), `unknown schema source "toml"`) {
+		t.Errorf("NewSchemaHandler() error = %v, want unknown schema source", err)
+	}
+}
+
+func TestFormHandler_Methods(t *testing.T) {
+	spec := `{
+		"openapi": "3.1.0",
+		"paths": {
+			"/pets/{id}": {
+				"put": {
+					"operationId": "updatePet",
+					"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}],
+					"requestBody": {"content": {"application/json": {"schema": {
+						"type": "object",
+						"properties": {"name": {"type": "string"}},
+						"required": ["name"]
+					}}}}
+				},
+				"get": {
+					"operationId": "findPets",
+					"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}]
+				}
+			}
+		}
+	}`
+
+	tests := []struct {
+		name         string
+		operation    string
+		method       string
+		target       string
+		values       url.Values
+		wantStatus   int
+		wantLocation string
+		wantData     string
+		wantContains []string
+	}{
+		{
+			name:         "render a PUT form",
+			operation:    "updatePet",
+			method:       http.MethodGet,
+			target:       "/pets/7",
+			wantStatus:   http.StatusOK,
+			wantContains: []string{`<form method="POST" action="/pets/{id}" class="form" data-action="/pets/{id}"><input type="hidden" name="_method" value="PUT">`},
+		},
+		{
+			name:         "submit a PUT form",
+			operation:    "updatePet",
+			method:       http.MethodPost,
+			target:       "/pets/7",
+			values:       url.Values{lib.MethodParam: {"PUT"}, "id": {"7"}, "name": {"Rex"}},
+			wantStatus:   http.StatusSeeOther,
+			wantLocation: "/pets/7",
+			wantData:     `{"id":7,"name":"Rex"}`,
+		},
+		{
+			name:         "invalid PUT submission",
+			operation:    "updatePet",
+			method:       http.MethodPost,
+			target:       "/pets/7",
+			values:       url.Values{lib.MethodParam: {"put"}, "id": {"7"}},
+			wantStatus:   http.StatusUnprocessableEntity,
+			wantContains: []string{`<span id="name-error" class="field-error">This field is required</span>`},
+		},
+		{
+			name:       "PUT form submitted without its method",
+			operation:  "updatePet",
+			method:     http.MethodPost,
+			target:     "/pets/7",
+			values:     url.Values{"id": {"7"}, "name": {"Rex"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "render a GET form",
+			operation:    "findPets",
+			method:       http.MethodGet,
+			target:       "/pets",
+			wantStatus:   http.StatusOK,
+			wantContains: []string{`<form method="GET" action="/pets/{id}" class="form" data-action="/pets/{id}">`},
+		},
+		{
+			name:         "submit a GET form",
+			operation:    "findPets",
+			method:       http.MethodGet,
+			target:       "/pets?id=7",
+			wantStatus:   http.StatusSeeOther,
+			wantLocation: "/pets",
+			wantData:     `{"id":7}`,
+		},
+		{
+			name:       "POST to a GET form",
+			operation:  "findPets",
+			method:     http.MethodPost,
+			target:     "/pets",
+			values:     url.Values{"id": {"7"}},
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var submitted string
+			handler, err := NewSchemaHandler("openapi", []byte(spec), tt.operation, func(ctx context.Context, data json.RawMessage) error {
+				submitted = string(data)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("NewSchemaHandler() error = %v", err)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.values.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d. Body: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if got := resp.Header.Get("Location"); got != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", got, tt.wantLocation)
+			}
+			if submitted != tt.wantData {
+				t.Errorf("submitted data = %s, want %s", submitted, tt.wantData)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(string(body), want) {
+					t.Errorf("body does not contain %q. Body: %s", want, body)
+				}
+			}
+		})
+	}
+}
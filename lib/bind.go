@@ -0,0 +1,350 @@
+package lib
+
+import (
+	"encoding"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// timeLayouts are the layouts time values are parsed with when bound: RFC 3339 date-times
+// and full-times as decoded, and the local formats date, time and datetime-local inputs use
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	time.DateOnly,
+	"15:04:05Z07:00",
+	time.TimeOnly,
+	"15:04",
+}
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// Bind decodes a submitted form like DecodeRequest, validates it against the variants its
+// selectors chose like ValidateValuesWithVariants and stores the values in the struct dst
+// points to, like BindValues. Values that cannot be decoded, invalid values and values that cannot be stored in their struct field are all
+// reported as FieldErrors keyed by field path, the first error of each field winning; the
+// other values are stored regardless.
+func (f *Form) Bind(r *http.Request, dst any) error {
+	values, err := f.DecodeRequest(r)
+	errs, ok := err.(FieldErrors)
+	if err != nil && !ok {
+		return err
+	}
+
+	errs = errs.Merge(f.ValidateValuesWithVariants(values, f.DecodeVariants(r.Form)))
+	if bindErr := BindValues(values, dst); bindErr != nil {
+		bindErrs, ok := bindErr.(FieldErrors)
+		if !ok {
+			return bindErr
+		}
+		errs = errs.Merge(bindErrs)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// BindValues stores decoded values, as returned by DecodeValues, in the struct dst points
+// to. Properties are matched to struct fields by their json tags the way encoding/json
+// matches them, including fields promoted from embedded structs; properties without a
+// value leave their field untouched. Pointers are allocated as needed, so optional
+// properties can be told apart from zero values. Time values are parsed from RFC 3339 and
+// from the formats of date, time and datetime-local inputs, and byte slices from the base64
+// encoding file uploads are decoded to. Values that cannot be stored in their field are
+// reported as FieldErrors keyed by field path.
+func BindValues(values map[string]any, dst any) error {
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("destination must be a non-nil pointer to a struct, got %T", dst)
+	}
+
+	errs := FieldErrors{}
+	bindStruct(target.Elem(), values, "", errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// bindStruct stores the properties of an object in the fields of a struct
+func bindStruct(target reflect.Value, values map[string]any, path string, errs FieldErrors) {
+	for _, field := range jsonFields(target.Type()) {
+		value, ok := values[field.name]
+		if !ok {
+			continue
+		}
+		fieldValue, ok := fieldByIndex(target, field.index)
+		if !ok {
+			continue
+		}
+		bindValue(fieldValue, value, joinPath(path, field.name), errs)
+	}
+}
+
+// bindValue stores a single value in target, recording an error if it does not fit
+func bindValue(target reflect.Value, value any, path string, errs FieldErrors) {
+	if value == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return
+	}
+
+	if target.Kind() == reflect.Pointer {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		bindValue(target.Elem(), value, path, errs)
+		return
+	}
+
+	if message, ok := bindText(target, value); ok {
+		if message != "" {
+			errs[path] = message
+		}
+		return
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		text, ok := value.(string)
+		if !ok {
+			errs[path] = "Must be text"
+			return
+		}
+		target.SetString(text)
+
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			errs[path] = "Must be true or false"
+			return
+		}
+		target.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, ok := toFloat(value)
+		if !ok || number != math.Trunc(number) {
+			errs[path] = "Must be a whole number"
+			return
+		}
+		if number < math.MinInt64 || number >= math.MaxInt64 || target.OverflowInt(int64(number)) {
+			errs[path] = "Is out of range"
+			return
+		}
+		target.SetInt(int64(number))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, ok := toFloat(value)
+		if !ok || number != math.Trunc(number) {
+			errs[path] = "Must be a whole number"
+			return
+		}
+		if number < 0 || number >= math.MaxUint64 || target.OverflowUint(uint64(number)) {
+			errs[path] = "Is out of range"
+			return
+		}
+		target.SetUint(uint64(number))
+
+	case reflect.Float32, reflect.Float64:
+		number, ok := toFloat(value)
+		if !ok {
+			errs[path] = "Must be a number"
+			return
+		}
+		if target.OverflowFloat(number) {
+			errs[path] = "Is out of range"
+			return
+		}
+		target.SetFloat(number)
+
+	case reflect.Struct:
+		nested, ok := value.(map[string]any)
+		if !ok {
+			errs[path] = "Must be an object"
+			return
+		}
+		bindStruct(target, nested, path, errs)
+
+	case reflect.Map:
+		nested, ok := value.(map[string]any)
+		if !ok || target.Type().Key().Kind() != reflect.String {
+			errs[path] = "Must be an object"
+			return
+		}
+		result := reflect.MakeMapWithSize(target.Type(), len(nested))
+		for key, item := range nested {
+			element := reflect.New(target.Type().Elem()).Elem()
+			bindValue(element, item, joinPath(path, key), errs)
+			result.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), element)
+		}
+		target.Set(result)
+
+	case reflect.Slice:
+		items, ok := toSlice(value)
+		if !ok {
+			errs[path] = "Must be a list"
+			return
+		}
+		result := reflect.MakeSlice(target.Type(), len(items), len(items))
+		for i, item := range items {
+			bindValue(result.Index(i), item, fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+		target.Set(result)
+
+	case reflect.Array:
+		items, ok := toSlice(value)
+		if !ok {
+			errs[path] = "Must be a list"
+			return
+		}
+		if len(items) > target.Len() {
+			errs[path] = fmt.Sprintf("Must have at most %d items", target.Len())
+			return
+		}
+		target.Set(reflect.Zero(target.Type()))
+		for i, item := range items {
+			bindValue(target.Index(i), item, fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+
+	case reflect.Interface:
+		if !reflect.TypeOf(value).AssignableTo(target.Type()) {
+			errs[path] = "Has an unexpected type"
+			return
+		}
+		target.Set(reflect.ValueOf(value))
+
+	default:
+		errs[path] = fmt.Sprintf("Cannot be stored in a %s", target.Type())
+	}
+}
+
+// bindText stores a string in a time, byte slice or text unmarshaler, which take their
+// values from text rather than from values of their kind. It reports whether target is
+// one of them, along with an error message or "".
+func bindText(target reflect.Value, value any) (string, bool) {
+	text, ok := value.(string)
+	if !ok {
+		return "", false
+	}
+
+	switch {
+	case target.Type() == timeType:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, text); err == nil {
+				target.Set(reflect.ValueOf(t))
+				return "", true
+			}
+		}
+		return "Must be a date or time", true
+
+	case isBytes(target.Type()):
+		contents, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return "Must be base64 encoded", true
+		}
+		target.SetBytes(contents)
+		return "", true
+
+	case reflect.PointerTo(target.Type()).Implements(textUnmarshalerType):
+		if err := target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+			return "Invalid format", true
+		}
+		return "", true
+	}
+	return "", false
+}
+
+// isBytes reports whether t is a byte slice
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// jsonField is a struct field, possibly promoted from an embedded struct, and its JSON name
+type jsonField struct {
+	name  string
+	index []int
+}
+
+// jsonFields returns the fields of a struct type by JSON name. As in encoding/json, fields
+// promoted from embedded structs without a json name are included unless a shallower field
+// has the same name.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	seen := make(map[string]bool)
+	var embedded []reflect.StructField
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Pointer {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				embedded = append(embedded, field)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if !seen[name] {
+			seen[name] = true
+			fields = append(fields, jsonField{name: name, index: field.Index})
+		}
+	}
+
+	for _, field := range embedded {
+		embeddedType := field.Type
+		if embeddedType.Kind() == reflect.Pointer {
+			embeddedType = embeddedType.Elem()
+		}
+		for _, promoted := range jsonFields(embeddedType) {
+			if !seen[promoted.name] {
+				seen[promoted.name] = true
+				index := append(append([]int(nil), field.Index...), promoted.index...)
+				fields = append(fields, jsonField{name: promoted.name, index: index})
+			}
+		}
+	}
+
+	return fields
+}
+
+// fieldByIndex returns the field of a struct at a promoted field index, allocating the
+// embedded struct pointers on the way. Fields behind unexported embedded pointers cannot
+// be set and are reported as not found.
+func fieldByIndex(target reflect.Value, index []int) (reflect.Value, bool) {
+	for i, step := range index {
+		if i > 0 && target.Kind() == reflect.Pointer {
+			if target.IsNil() {
+				if !target.CanSet() {
+					return reflect.Value{}, false
+				}
+				target.Set(reflect.New(target.Type().Elem()))
+			}
+			target = target.Elem()
+		}
+		target = target.Field(step)
+	}
+	return target, target.CanSet()
+}
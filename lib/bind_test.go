@@ -0,0 +1,218 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type bindAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+type bindAudit struct {
+	CreatedBy string `json:"createdBy"`
+}
+
+type bindTarget struct {
+	bindAudit
+	Name     string            `json:"name"`
+	Age      int               `json:"age,omitempty"`
+	Score    *float64          `json:"score"`
+	Active   bool              `json:"active"`
+	Address  bindAddress       `json:"address"`
+	Previous *bindAddress      `json:"previous"`
+	Tags     []string          `json:"tags"`
+	Sizes    [2]uint8          `json:"sizes"`
+	Born     time.Time         `json:"born"`
+	Alarm    *time.Time        `json:"alarm"`
+	Avatar   []byte            `json:"avatar"`
+	Labels   map[string]string `json:"labels"`
+	Extra    any               `json:"extra"`
+	Ignored  string            `json:"-"`
+	Untagged string
+}
+
+func TestBindValues(t *testing.T) {
+	score := 9.5
+
+	tests := []struct {
+		name    string
+		values  map[string]any
+		want    bindTarget
+		wantErr FieldErrors
+	}{
+		{
+			name:   "scalars",
+			values: map[string]any{"name": "Alice", "age": int64(42), "active": true, "Untagged": "kept"},
+			want:   bindTarget{Name: "Alice", Age: 42, Active: true, Untagged: "kept"},
+		},
+		{
+			name:   "pointers are allocated for present values",
+			values: map[string]any{"score": 9.5, "previous": map[string]any{"city": "Oslo"}},
+			want:   bindTarget{Score: &score, Previous: &bindAddress{City: "Oslo"}},
+		},
+		{
+			name:   "nested structs and embedded fields",
+			values: map[string]any{"address": map[string]any{"street": "Main St", "city": "Oslo"}, "createdBy": "admin"},
+			want:   bindTarget{bindAudit: bindAudit{CreatedBy: "admin"}, Address: bindAddress{Street: "Main St", City: "Oslo"}},
+		},
+		{
+			name:   "slices arrays and maps",
+			values: map[string]any{"tags": []any{"go", "rust"}, "sizes": []any{int64(1), int64(2)}, "labels": map[string]any{"team": "forms"}},
+			want:   bindTarget{Tags: []string{"go", "rust"}, Sizes: [2]uint8{1, 2}, Labels: map[string]string{"team": "forms"}},
+		},
+		{
+			name:   "time values",
+			values: map[string]any{"born": "1990-05-17", "alarm": "07:30:00Z"},
+			want: bindTarget{
+				Born:  time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+				Alarm: func() *time.Time { t := time.Date(0, 1, 1, 7, 30, 0, 0, time.UTC); return &t }(),
+			},
+		},
+		{
+			name:   "base64 bytes and interfaces",
+			values: map[string]any{"avatar": "aGk=", "extra": []any{1.0, "two"}},
+			want:   bindTarget{Avatar: []byte("hi"), Extra: []any{1.0, "two"}},
+		},
+		{
+			name:   "ignored fields are not bound",
+			values: map[string]any{"Ignored": "no", "-": "no", "unknown": "no"},
+			want:   bindTarget{},
+		},
+		{
+			name: "values that do not fit are reported by path",
+			values: map[string]any{
+				"name":    "Alice",
+				"age":     2.5,
+				"active":  "yes",
+				"address": map[string]any{"street": 12.0},
+				"tags":    []any{"go", true},
+				"sizes":   []any{int64(300)},
+				"born":    "yesterday",
+			},
+			want: bindTarget{Name: "Alice", Tags: []string{"go", ""}},
+			wantErr: FieldErrors{
+				"age":            "Must be a whole number",
+				"active":         "Must be true or false",
+				"address.street": "Must be text",
+				"tags[1]":        "Must be text",
+				"sizes[0]":       "Is out of range",
+				"born":           "Must be a date or time",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bindTarget
+			err := BindValues(tt.values, &got)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("BindValues() error = %v", err)
+			}
+			if tt.wantErr != nil && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("BindValues() error = %#v, want %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BindValues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindValues_InvalidDestination(t *testing.T) {
+	for _, dst := range []any{nil, bindTarget{}, (*bindTarget)(nil), new(string)} {
+		if err := BindValues(map[string]any{}, dst); err == nil {
+			t.Errorf("BindValues(%T) error = nil, want error", dst)
+		}
+	}
+}
+
+func TestForm_Bind(t *testing.T) {
+	form := &Form{
+		Method: "POST",
+		Fields: []Field{
+			{Name: "name", Type: FieldTypeText, Validation: &Validation{Required: true}},
+			{Name: "age", Type: FieldTypeNumber, DataType: DataTypeInteger, Validation: &Validation{Max: floatPtr(150)}},
+			{Name: "born", Type: FieldTypeDate},
+			{Name: "address", Type: FieldTypeObject, Fields: []Field{
+				{Name: "street", Type: FieldTypeText},
+				{Name: "city", Type: FieldTypeText},
+			}},
+			{Name: "tags", Type: FieldTypeArray, Fields: []Field{{Name: "item", Type: FieldTypeText}}},
+		},
+	}
+
+	newRequest := func(values url.Values) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(values.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	t.Run("valid submission", func(t *testing.T) {
+		var got bindTarget
+		err := form.Bind(newRequest(url.Values{
+			"name":           {"Alice"},
+			"age":            {"42"},
+			"born":           {"1990-05-17"},
+			"address.street": {"Main St"},
+			"tags[0]":        {"go"},
+		}), &got)
+		if err != nil {
+			t.Fatalf("Form.Bind() error = %v", err)
+		}
+
+		want := bindTarget{
+			Name:    "Alice",
+			Age:     42,
+			Born:    time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+			Address: bindAddress{Street: "Main St"},
+			Tags:    []string{"go"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Form.Bind() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("validation errors are keyed by path", func(t *testing.T) {
+		var got bindTarget
+		err := form.Bind(newRequest(url.Values{"age": {"200"}, "born": {"1990-05-17"}}), &got)
+
+		want := FieldErrors{"name": "This field is required", "age": "Must be at most 150"}
+		if !reflect.DeepEqual(err, want) {
+			t.Fatalf("Form.Bind() error = %#v, want %#v", err, want)
+		}
+		if got.Age != 200 || got.Born.IsZero() {
+			t.Errorf("Form.Bind() = %+v, want the submitted values bound", got)
+		}
+	})
+
+	t.Run("values that do not fit are reported by path", func(t *testing.T) {
+		var got struct {
+			Name string `json:"name"`
+			Age  int8   `json:"age"`
+		}
+		err := form.Bind(newRequest(url.Values{"name": {"Alice"}, "age": {"140"}}), &got)
+
+		want := FieldErrors{"age": "Is out of range"}
+		if !reflect.DeepEqual(err, want) {
+			t.Fatalf("Form.Bind() error = %#v, want %#v", err, want)
+		}
+	})
+
+	t.Run("undecodable values are reported with the other errors", func(t *testing.T) {
+		var got bindTarget
+		err := form.Bind(newRequest(url.Values{"age": {"abc"}}), &got)
+
+		want := FieldErrors{"name": "This field is required", "age": "Must be a whole number"}
+		if !reflect.DeepEqual(err, want) {
+			t.Fatalf("Form.Bind() error = %#v, want %#v", err, want)
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package lib
+
+import (
+	"fmt"
+)
+
+// ConditionOperator identifies how a Condition is evaluated
+type ConditionOperator string
+
+const (
+	ConditionEquals  ConditionOperator = "equals"  // Field equals Value
+	ConditionIn      ConditionOperator = "in"      // Field equals one of Values
+	ConditionRange   ConditionOperator = "range"   // Field is a number between Min and Max
+	ConditionPresent ConditionOperator = "present" // Field has a non-empty value
+	ConditionAnd     ConditionOperator = "and"     // Every one of Conditions holds
+	ConditionOr      ConditionOperator = "or"      // At least one of Conditions holds
+	ConditionNot     ConditionOperator = "not"     // The single condition in Conditions does not hold
+)
+
+// Condition represents a condition on the values of sibling fields
+type Condition struct {
+	Operator     ConditionOperator `json:"operator"`
+	Field        string            `json:"field,omitempty"`        // Name of the sibling field tested by equals, in, range and present
+	Value        any               `json:"value,omitempty"`        // For equals
+	Values       []any             `json:"values,omitempty"`       // For in
+	Min          *float64          `json:"min,omitempty"`          // For range
+	Max          *float64          `json:"max,omitempty"`          // For range
+	ExclusiveMin bool              `json:"exclusiveMin,omitempty"` // Min itself is out of range
+	ExclusiveMax bool              `json:"exclusiveMax,omitempty"` // Max itself is out of range
+	Conditions   []Condition       `json:"conditions,omitempty"`   // For and, or and not
+}
+
+// Met reports whether the condition holds for the given sibling values
+func (c *Condition) Met(values map[string]any) bool {
+	value := values[c.Field]
+
+	switch c.Operator {
+	case ConditionEquals:
+		return !isEmptyValue(value) && sameValue(value, c.Value)
+	case ConditionIn:
+		if isEmptyValue(value) {
+			return false
+		}
+		for _, candidate := range c.Values {
+			if sameValue(value, candidate) {
+				return true
+			}
+		}
+		return false
+	case ConditionRange:
+		number, ok := toFloat(value)
+		if !ok {
+			return false
+		}
+		if c.Min != nil && (number < *c.Min || (c.ExclusiveMin && number == *c.Min)) {
+			return false
+		}
+		if c.Max != nil && (number > *c.Max || (c.ExclusiveMax && number == *c.Max)) {
+			return false
+		}
+		return true
+	case ConditionPresent:
+		return !isEmptyValue(value)
+	case ConditionAnd:
+		for i := range c.Conditions {
+			if !c.Conditions[i].Met(values) {
+				return false
+			}
+		}
+		return true
+	case ConditionOr:
+		for i := range c.Conditions {
+			if c.Conditions[i].Met(values) {
+				return true
+			}
+		}
+		return false
+	case ConditionNot:
+		return len(c.Conditions) == 1 && !c.Conditions[0].Met(values)
+	}
+	return false
+}
+
+// FieldNames returns the names of the fields the condition tests, in order of appearance
+func (c *Condition) FieldNames() []string {
+	var names []string
+	seen := make(map[string]bool)
+	var collect func(*Condition)
+	collect = func(condition *Condition) {
+		if condition.Field != "" && !seen[condition.Field] {
+			seen[condition.Field] = true
+			names = append(names, condition.Field)
+		}
+		for i := range condition.Conditions {
+			collect(&condition.Conditions[i])
+		}
+	}
+	collect(c)
+	return names
+}
+
+// Rule returns the condition deciding between the Then and Else fields: When if set,
+// otherwise the Condition field equaling Value, or having a value other than false if
+// Value is nil
+func (c *ConditionalField) Rule() *Condition {
+	if c.When != nil {
+		return c.When
+	}
+	if c.Value == nil {
+		return &Condition{Operator: ConditionAnd, Conditions: []Condition{
+			{Operator: ConditionPresent, Field: c.Condition},
+			{Operator: ConditionNot, Conditions: []Condition{{Operator: ConditionEquals, Field: c.Condition, Value: false}}},
+		}}
+	}
+	return &Condition{Operator: ConditionEquals, Field: c.Condition, Value: c.Value}
+}
+
+// Met reports whether the rule of a conditional field holds for the given sibling values
+func (c *ConditionalField) Met(values map[string]any) bool {
+	return c.Rule().Met(values)
+}
+
+// ApplyRequiredWhen returns the field, or a copy of it marked required if its RequiredWhen
+// condition holds for the given sibling values
+func (f *Field) ApplyRequiredWhen(values map[string]any) *Field {
+	validation := f.Validation
+	if validation == nil || validation.RequiredWhen == nil || validation.Required || !validation.RequiredWhen.Met(values) {
+		return f
+	}
+
+	required := *validation
+	required.Required = true
+	field := *f
+	field.Validation = &required
+	return &field
+}
+
+// validateCondition validates a condition and that the fields it tests exist in the parent scope
+func validateCondition(condition *Condition, parentFieldNames map[string]bool, path string) error {
+	switch condition.Operator {
+	case ConditionEquals, ConditionIn, ConditionRange, ConditionPresent:
+		if condition.Field == "" {
+			return fmt.Errorf("%s: condition '%s' must specify a field name", path, condition.Operator)
+		}
+		if !parentFieldNames[condition.Field] {
+			return fmt.Errorf("%s: condition references non-existent field '%s'", path, condition.Field)
+		}
+		if condition.Operator == ConditionIn && len(condition.Values) == 0 {
+			return fmt.Errorf("%s: condition 'in' requires at least one value", path)
+		}
+		if condition.Operator == ConditionRange && condition.Min == nil && condition.Max == nil {
+			return fmt.Errorf("%s: condition 'range' requires a min or max", path)
+		}
+		return nil
+
+	case ConditionAnd, ConditionOr, ConditionNot:
+		if condition.Operator == ConditionNot && len(condition.Conditions) != 1 {
+			return fmt.Errorf("%s: condition 'not' requires exactly one condition, got %d", path, len(condition.Conditions))
+		}
+		if len(condition.Conditions) == 0 {
+			return fmt.Errorf("%s: condition '%s' requires at least one condition", path, condition.Operator)
+		}
+		for i := range condition.Conditions {
+			if err := validateCondition(&condition.Conditions[i], parentFieldNames, fmt.Sprintf("%s.conditions[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%s: invalid condition operator '%s'", path, condition.Operator)
+}
+
+// sameValue reports whether two values are equal once formatted, so that decoded and
+// schema values of different Go types (e.g. int64 and float64) compare equal
+func sameValue(a, b any) bool {
+	return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
+}
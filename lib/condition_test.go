@@ -0,0 +1,104 @@
+package lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCondition_Met(t *testing.T) {
+	eighteen, sixtyFive := 18.0, 65.0
+	adult := Condition{Operator: ConditionRange, Field: "age", Min: &eighteen, Max: &sixtyFive, ExclusiveMax: true}
+	nordic := Condition{Operator: ConditionIn, Field: "country", Values: []any{"SE", "NO", "DK"}}
+
+	tests := []struct {
+		name      string
+		condition Condition
+		values    map[string]any
+		want      bool
+	}{
+		{name: "equals", condition: Condition{Operator: ConditionEquals, Field: "country", Value: "SE"}, values: map[string]any{"country": "SE"}, want: true},
+		{name: "equals other value", condition: Condition{Operator: ConditionEquals, Field: "country", Value: "SE"}, values: map[string]any{"country": "NO"}, want: false},
+		{name: "equals across number types", condition: Condition{Operator: ConditionEquals, Field: "count", Value: float64(2)}, values: map[string]any{"count": int64(2)}, want: true},
+		{name: "equals missing value", condition: Condition{Operator: ConditionEquals, Field: "country", Value: "SE"}, values: map[string]any{}, want: false},
+		{name: "in", condition: nordic, values: map[string]any{"country": "NO"}, want: true},
+		{name: "not in", condition: nordic, values: map[string]any{"country": "US"}, want: false},
+		{name: "range lower bound", condition: adult, values: map[string]any{"age": int64(18)}, want: true},
+		{name: "range below", condition: adult, values: map[string]any{"age": int64(17)}, want: false},
+		{name: "range exclusive upper bound", condition: adult, values: map[string]any{"age": float64(65)}, want: false},
+		{name: "range non-number", condition: adult, values: map[string]any{"age": "old"}, want: false},
+		{name: "present", condition: Condition{Operator: ConditionPresent, Field: "name"}, values: map[string]any{"name": "Ada"}, want: true},
+		{name: "present empty", condition: Condition{Operator: ConditionPresent, Field: "name"}, values: map[string]any{"name": ""}, want: false},
+		{
+			name:      "and",
+			condition: Condition{Operator: ConditionAnd, Conditions: []Condition{adult, nordic}},
+			values:    map[string]any{"age": int64(30), "country": "US"},
+			want:      false,
+		},
+		{
+			name:      "or",
+			condition: Condition{Operator: ConditionOr, Conditions: []Condition{adult, nordic}},
+			values:    map[string]any{"age": int64(30), "country": "US"},
+			want:      true,
+		},
+		{
+			name:      "not",
+			condition: Condition{Operator: ConditionNot, Conditions: []Condition{nordic}},
+			values:    map[string]any{"country": "US"},
+			want:      true,
+		},
+		{name: "unknown operator", condition: Condition{Operator: "matches", Field: "name"}, values: map[string]any{"name": "Ada"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.condition.Met(tt.values); got != tt.want {
+				t.Errorf("Condition.Met() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCondition_FieldNames(t *testing.T) {
+	condition := Condition{Operator: ConditionAnd, Conditions: []Condition{
+		{Operator: ConditionPresent, Field: "country"},
+		{Operator: ConditionOr, Conditions: []Condition{
+			{Operator: ConditionEquals, Field: "country", Value: "SE"},
+			{Operator: ConditionPresent, Field: "age"},
+		}},
+	}}
+
+	if got, want := condition.FieldNames(), []string{"country", "age"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Condition.FieldNames() = %v, want %v", got, want)
+	}
+}
+
+func TestConditionalField_Met(t *testing.T) {
+	tests := []struct {
+		name        string
+		conditional ConditionalField
+		values      map[string]any
+		want        bool
+	}{
+		{name: "condition value", conditional: ConditionalField{Condition: "country", Value: "US"}, values: map[string]any{"country": "US"}, want: true},
+		{name: "condition presence", conditional: ConditionalField{Condition: "has_vat"}, values: map[string]any{"has_vat": true}, want: true},
+		{name: "condition false", conditional: ConditionalField{Condition: "has_vat"}, values: map[string]any{"has_vat": false}, want: false},
+		{
+			name: "when takes precedence",
+			conditional: ConditionalField{
+				Condition: "country",
+				Value:     "US",
+				When:      &Condition{Operator: ConditionIn, Field: "country", Values: []any{"SE", "NO"}},
+			},
+			values: map[string]any{"country": "SE"},
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.conditional.Met(tt.values); got != tt.want {
+				t.Errorf("ConditionalField.Met() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
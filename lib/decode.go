@@ -0,0 +1,418 @@
+package lib
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxMultipartMemory is the amount of a multipart body kept in memory while parsing a request
+const maxMultipartMemory = 32 << 20
+
+// DecodeRequest parses a submitted form (URL encoded or multipart) and decodes it
+// into a JSON document shaped like the schema the form was generated from, like DecodeValues
+func (f *Form) DecodeRequest(r *http.Request) (map[string]any, error) {
+	if f == nil {
+		return nil, fmt.Errorf("form cannot be nil")
+	}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(maxMultipartMemory); err != nil {
+			return nil, fmt.Errorf("parsing multipart form: %w", err)
+		}
+	} else if err := r.ParseForm(); err != nil {
+		return nil, fmt.Errorf("parsing form: %w", err)
+	}
+
+	d := &decoder{values: r.Form, naming: f.Naming, errs: FieldErrors{}}
+	if r.MultipartForm != nil {
+		d.files = r.MultipartForm.File
+	}
+	return d.decode(f.Fields)
+}
+
+// DecodeValues decodes submitted form values into a JSON document shaped like the
+// schema the form was generated from. Values are coerced according to each field's
+// DataType and options; empty inputs are left out so optional properties stay absent.
+// Nested fields are read from names qualified according to the form's Naming scheme.
+// Values that cannot be coerced, such as "abc" for an integer, are reported as FieldErrors
+// keyed by field path. The document is returned along with them, holding such values as
+// the strings submitted, so the form can be re-rendered with them by Populate.
+func (f *Form) DecodeValues(values url.Values) (map[string]any, error) {
+	if f == nil {
+		return nil, fmt.Errorf("form cannot be nil")
+	}
+
+	d := &decoder{values: values, naming: f.Naming, errs: FieldErrors{}}
+	return d.decode(f.Fields)
+}
+
+// DecodeVariants returns the variants chosen by the selectors of submitted form values,
+// keyed by the paths of their variant fields. Pass them to ValidateValuesWithVariants and
+// PopulateWithVariants to validate and re-render each value with the variant it was submitted
+// for, which the decoded value alone may not tell. Items are counted as EditItems does when
+// an add or remove item button was pressed, so the paths match the document it returns.
+func (f *Form) DecodeVariants(values url.Values) SelectedVariants {
+	if f == nil {
+		return nil
+	}
+
+	d := &decoder{
+		values:     values,
+		naming:     f.Naming,
+		addItem:    values.Get(AddItemParam),
+		removeItem: values.Get(RemoveItemParam),
+		selected:   SelectedVariants{},
+	}
+	if _, err := d.decodeFields(f.Fields, "", ""); err != nil {
+		return nil
+	}
+	return d.selected
+}
+
+// DecodeJSON decodes submitted form values like DecodeValues and marshals the result
+func (f *Form) DecodeJSON(values url.Values) (json.RawMessage, error) {
+	decoded, err := f.DecodeValues(values)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(decoded)
+}
+
+// decoder holds the submitted values of a single decode
+type decoder struct {
+	values     url.Values
+	files      map[string][]*multipart.FileHeader
+	naming     NamingScheme
+	addItem    string           // Qualified name of an array to append an empty item to
+	removeItem string           // Qualified name of an array item to leave out
+	errs       FieldErrors      // Values that cannot be coerced, by field path
+	selected   SelectedVariants // Variants chosen by the submitted selectors, by field path
+}
+
+// decode decodes the top-level fields of a form, returning the values that cannot be
+// coerced as FieldErrors along with the document
+func (d *decoder) decode(fields []Field) (map[string]any, error) {
+	result, err := d.decodeFields(fields, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if len(d.errs) > 0 {
+		return result, d.errs
+	}
+	return result, nil
+}
+
+// decodeFields decodes a list of sibling fields nested in the field with the qualified
+// name parent into an object
+func (d *decoder) decodeFields(fields []Field, parent, path string) (map[string]any, error) {
+	result := make(map[string]any)
+	for i := range fields {
+		if err := d.decodeInto(result, &fields[i], parent, path); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// decodeInto decodes a field, and any conditional fields it carries, into result
+func (d *decoder) decodeInto(result map[string]any, field *Field, parent, path string) error {
+	if field.Name != "" {
+		value, present, err := d.decodeField(field, d.naming.Child(parent, field.Name), joinPath(path, field.Name))
+		if err != nil {
+			return err
+		}
+		if present {
+			result[field.Name] = value
+		}
+	}
+
+	for _, conditional := range field.AllConditionals() {
+		for _, branch := range [][]Field{conditional.Then, conditional.Else} {
+			for i := range branch {
+				if err := d.decodeInto(result, &branch[i], parent, path); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// decodeField decodes the value of a single field submitted under the qualified name,
+// reporting whether a value was submitted
+func (d *decoder) decodeField(field *Field, name, path string) (any, bool, error) {
+	switch field.Type {
+	case FieldTypeObject:
+		nested, err := d.decodeFields(field.Fields, name, path)
+		if err != nil {
+			return nil, false, err
+		}
+		return nested, len(nested) > 0, nil
+
+	case FieldTypeArray:
+		return d.decodeArray(field, name, path)
+
+	case FieldTypeVariant:
+		return d.decodeVariant(field, name, path)
+
+	case FieldTypeFile:
+		return d.decodeFile(name, path)
+
+	case FieldTypeCheckbox:
+		if len(field.Options) == 0 && dataTypeOf(field) == DataTypeBoolean {
+			// Unchecked checkboxes are not submitted at all
+			raw := d.values.Get(name)
+			return raw != "" && raw != "false" && raw != "off", true, nil
+		}
+		return d.decodeMultiple(field, name, path)
+	}
+
+	raw := d.values.Get(name)
+	if raw == "" {
+		return nil, false, nil
+	}
+	return d.coerce(field, raw, path), true, nil
+}
+
+// decodeMultiple decodes every submitted value of a multi-valued field into a list
+func (d *decoder) decodeMultiple(field *Field, name, path string) (any, bool, error) {
+	items := []any{}
+	for _, raw := range d.values[name] {
+		if raw == "" {
+			continue
+		}
+		items = append(items, d.coerce(field, raw, fmt.Sprintf("%s[%d]", path, len(items))))
+	}
+	return items, len(items) > 0, nil
+}
+
+// decodeArray decodes the items of an array field from its item template field.
+// Items are submitted under indexed names; indexes need not be contiguous, so items
+// removed from the middle of a list leave no gaps. Empty items are left out.
+func (d *decoder) decodeArray(field *Field, name, path string) (any, bool, error) {
+	if len(field.Fields) != 1 {
+		return nil, false, nil
+	}
+	item := &field.Fields[0]
+
+	items := []any{}
+	for _, index := range itemIndexes(name, d.submittedNames()) {
+		itemName := d.naming.Item(name, index)
+		if itemName == d.removeItem {
+			continue
+		}
+		value, present, err := d.decodeField(item, itemName, fmt.Sprintf("%s[%d]", path, len(items)))
+		if err != nil {
+			return nil, false, err
+		}
+		if present {
+			items = append(items, value)
+		}
+	}
+	if name == d.addItem {
+		items = append(items, nil)
+	}
+	return items, len(items) > 0, nil
+}
+
+// submittedNames returns the names of every submitted value and file
+func (d *decoder) submittedNames() []string {
+	names := make([]string, 0, len(d.values)+len(d.files))
+	for name := range d.values {
+		names = append(names, name)
+	}
+	for name := range d.files {
+		names = append(names, name)
+	}
+	return names
+}
+
+// decodeVariant decodes the fields of the variant chosen by the field's selector, recording
+// its index in the decoder's selected variants. Object variants carry the selected
+// variant's Value in the discriminator property.
+func (d *decoder) decodeVariant(field *Field, name, path string) (any, bool, error) {
+	index := field.variantIndexByValue(d.values.Get(name))
+	if index < 0 {
+		return nil, false, nil
+	}
+	variant := &field.Variants[index]
+
+	var value any
+	var present bool
+	if variant.Scalar {
+		scalar := &variant.Fields[0]
+		decoded, ok, err := d.decodeField(scalar, d.naming.Child(name, scalar.Name), path)
+		if err != nil {
+			return nil, false, err
+		}
+		value, present = decoded, ok
+	} else {
+		nested, err := d.decodeFields(variant.Fields, name, path)
+		if err != nil {
+			return nil, false, err
+		}
+		if field.Discriminator != "" {
+			nested[field.Discriminator] = variant.Value
+		}
+		value, present = nested, len(nested) > 0
+	}
+
+	if present && d.selected != nil {
+		d.selected[path] = index
+	}
+	return value, present, nil
+}
+
+// decodeFile decodes an uploaded file into its base64 encoded contents
+func (d *decoder) decodeFile(name, path string) (any, bool, error) {
+	headers := d.files[name]
+	if len(headers) == 0 || headers[0].Size == 0 {
+		return nil, false, nil
+	}
+
+	file, err := headers[0].Open()
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: opening uploaded file: %w", path, err)
+	}
+	defer file.Close()
+
+	contents, err := io.ReadAll(file)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: reading uploaded file: %w", path, err)
+	}
+	return base64.StdEncoding.EncodeToString(contents), true, nil
+}
+
+// coerce converts a submitted string into the field's typed value, recording an error
+// for the field path and returning the string as is if it cannot be converted
+func (d *decoder) coerce(field *Field, raw, path string) any {
+	value, err := coerceValue(field, raw)
+	if err != nil {
+		if d.errs != nil {
+			d.errs[path] = err.Error()
+		}
+		return raw
+	}
+	return value
+}
+
+// coerceValue converts a submitted string into the field's typed value. Values of
+// fields with options are mapped back to the matching option's original value.
+func coerceValue(field *Field, raw string) (any, error) {
+	for _, option := range field.Options {
+		if fmt.Sprintf("%v", option.Value) == raw {
+			return option.Value, nil
+		}
+	}
+
+	switch dataTypeOf(field) {
+	case DataTypeInteger:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Must be a whole number")
+		}
+		return value, nil
+	case DataTypeNumber:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Must be a number")
+		}
+		return value, nil
+	case DataTypeBoolean:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("Must be true or false")
+		}
+		return value, nil
+	}
+
+	switch field.Type {
+	case FieldTypeDateTime:
+		return normalizeDateTime(raw)
+	case FieldTypeTime:
+		return normalizeTime(raw)
+	}
+
+	return raw, nil
+}
+
+// normalizeDateTime converts a datetime-local value into an RFC 3339 date-time.
+// datetime-local inputs carry no offset, so the value is interpreted as UTC.
+func normalizeDateTime(raw string) (string, error) {
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02T15:04:05", time.RFC3339} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.UTC().Format(time.RFC3339), nil
+		}
+	}
+	return "", fmt.Errorf("Must be a date and time")
+}
+
+// normalizeTime converts a time input value into an RFC 3339 full-time, interpreted as UTC
+func normalizeTime(raw string) (string, error) {
+	for _, layout := range []string{"15:04", "15:04:05", "15:04:05Z07:00"} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.UTC().Format("15:04:05Z07:00"), nil
+		}
+	}
+	return "", fmt.Errorf("Must be a time")
+}
+
+// dataTypeOf returns the field's DataType, deriving it from the field type if unset
+func dataTypeOf(field *Field) DataType {
+	if field.DataType != "" {
+		return field.DataType
+	}
+
+	switch field.Type {
+	case FieldTypeNumber:
+		return DataTypeNumber
+	case FieldTypeCheckbox:
+		if len(field.Options) > 0 {
+			return DataTypeArray
+		}
+		return DataTypeBoolean
+	case FieldTypeObject:
+		return DataTypeObject
+	case FieldTypeArray:
+		return DataTypeArray
+	case FieldTypeVariant:
+		return ""
+	}
+	return DataTypeString
+}
+
+// nestedFieldNames returns the names of fields and all of their nested fields
+func nestedFieldNames(fields []Field) []string {
+	var names []string
+	for _, field := range fields {
+		if field.Name != "" {
+			names = append(names, field.Name)
+		}
+		names = append(names, nestedFieldNames(field.Fields)...)
+		for _, variant := range field.Variants {
+			names = append(names, nestedFieldNames(variant.Fields)...)
+		}
+		for _, conditional := range field.AllConditionals() {
+			names = append(names, nestedFieldNames(conditional.Then)...)
+			names = append(names, nestedFieldNames(conditional.Else)...)
+		}
+	}
+	return names
+}
+
+// joinPath appends a field name to a dotted field path
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,341 @@
+package lib
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestForm_DecodeValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   []Field
+		values   url.Values
+		want     map[string]any
+		wantErrs FieldErrors
+	}{
+		{
+			name:   "text field",
+			fields: []Field{{Name: "username", Type: FieldTypeText}},
+			values: url.Values{"username": {"alice"}},
+			want:   map[string]any{"username": "alice"},
+		},
+		{
+			name:   "empty values are omitted",
+			fields: []Field{{Name: "username", Type: FieldTypeText}, {Name: "age", Type: FieldTypeNumber}},
+			values: url.Values{"username": {""}},
+			want:   map[string]any{},
+		},
+		{
+			name: "integer and number coercion",
+			fields: []Field{
+				{Name: "age", Type: FieldTypeNumber, DataType: DataTypeInteger},
+				{Name: "height", Type: FieldTypeNumber},
+			},
+			values: url.Values{"age": {"42"}, "height": {"1.85"}},
+			want:   map[string]any{"age": int64(42), "height": 1.85},
+		},
+		{
+			name:     "invalid integer",
+			fields:   []Field{{Name: "age", Type: FieldTypeNumber, DataType: DataTypeInteger}},
+			values:   url.Values{"age": {"4.2"}},
+			want:     map[string]any{"age": "4.2"},
+			wantErrs: FieldErrors{"age": "Must be a whole number"},
+		},
+		{
+			name:     "invalid number",
+			fields:   []Field{{Name: "height", Type: FieldTypeNumber}},
+			values:   url.Values{"height": {"tall"}},
+			want:     map[string]any{"height": "tall"},
+			wantErrs: FieldErrors{"height": "Must be a number"},
+		},
+		{
+			name: "boolean checkbox",
+			fields: []Field{
+				{Name: "subscribe", Type: FieldTypeCheckbox},
+				{Name: "terms", Type: FieldTypeCheckbox},
+			},
+			values: url.Values{"subscribe": {"on"}},
+			want:   map[string]any{"subscribe": true, "terms": false},
+		},
+		{
+			name: "options map back to typed values",
+			fields: []Field{
+				{Name: "rating", Type: FieldTypeRadio, Options: []Option{{Label: "One", Value: 1.0}, {Label: "Two", Value: 2.0}}},
+				{Name: "size", Type: FieldTypeSelect, Options: []Option{{Label: "Small", Value: "s"}}},
+			},
+			values: url.Values{"rating": {"2"}, "size": {"s"}},
+			want:   map[string]any{"rating": 2.0, "size": "s"},
+		},
+		{
+			name: "checkbox group",
+			fields: []Field{
+				{Name: "tags", Type: FieldTypeCheckbox, Options: []Option{{Label: "Go", Value: "go"}, {Label: "Rust", Value: "rust"}}},
+			},
+			values: url.Values{"tags": {"go", "rust"}},
+			want:   map[string]any{"tags": []any{"go", "rust"}},
+		},
+		{
+			name:   "date and time values",
+			fields: []Field{{Name: "day", Type: FieldTypeDate}, {Name: "at", Type: FieldTypeDateTime}, {Name: "start", Type: FieldTypeTime}},
+			values: url.Values{"day": {"2024-05-01"}, "at": {"2024-05-01T13:30"}, "start": {"09:15"}},
+			want:   map[string]any{"day": "2024-05-01", "at": "2024-05-01T13:30:00Z", "start": "09:15:00Z"},
+		},
+		{
+			name:     "invalid date-time",
+			fields:   []Field{{Name: "at", Type: FieldTypeDateTime}},
+			values:   url.Values{"at": {"yesterday"}},
+			want:     map[string]any{"at": "yesterday"},
+			wantErrs: FieldErrors{"at": "Must be a date and time"},
+		},
+		{
+			name: "nested object",
+			fields: []Field{
+				{Name: "address", Type: FieldTypeObject, Fields: []Field{
+					{Name: "street", Type: FieldTypeText},
+					{Name: "zip", Type: FieldTypeNumber, DataType: DataTypeInteger},
+				}},
+			},
+			values: url.Values{"address.street": {"Main St"}, "address.zip": {"12345"}},
+			want:   map[string]any{"address": map[string]any{"street": "Main St", "zip": int64(12345)}},
+		},
+		{
+			name: "empty nested object is omitted",
+			fields: []Field{
+				{Name: "address", Type: FieldTypeObject, Fields: []Field{{Name: "street", Type: FieldTypeText}}},
+			},
+			values: url.Values{},
+			want:   map[string]any{},
+		},
+		{
+			name: "array of scalars",
+			fields: []Field{
+				{Name: "scores", Type: FieldTypeArray, Fields: []Field{{Name: "item", Type: FieldTypeNumber, DataType: DataTypeInteger}}},
+			},
+			values: url.Values{"scores[0]": {"1"}, "scores[1]": {""}, "scores[4]": {"3"}},
+			want:   map[string]any{"scores": []any{int64(1), int64(3)}},
+		},
+		{
+			name: "invalid array item",
+			fields: []Field{
+				{Name: "scores", Type: FieldTypeArray, Fields: []Field{{Name: "item", Type: FieldTypeNumber, DataType: DataTypeInteger}}},
+			},
+			values:   url.Values{"scores[0]": {"1"}, "scores[2]": {"x"}},
+			want:     map[string]any{"scores": []any{int64(1), "x"}},
+			wantErrs: FieldErrors{"scores[1]": "Must be a whole number"},
+		},
+		{
+			name: "array of objects",
+			fields: []Field{
+				{Name: "lines", Type: FieldTypeArray, Fields: []Field{
+					{Name: "item", Type: FieldTypeObject, Fields: []Field{
+						{Name: "sku", Type: FieldTypeText},
+						{Name: "qty", Type: FieldTypeNumber, DataType: DataTypeInteger},
+					}},
+				}},
+			},
+			values: url.Values{"lines[0].sku": {"a"}, "lines[0].qty": {"1"}, "lines[1].sku": {"b"}, "lines[1].qty": {"2"}},
+			want: map[string]any{"lines": []any{
+				map[string]any{"sku": "a", "qty": int64(1)},
+				map[string]any{"sku": "b", "qty": int64(2)},
+			}},
+		},
+		{
+			name: "conditional fields",
+			fields: []Field{
+				{Name: "country", Type: FieldTypeText, Conditional: &ConditionalField{
+					Condition: "country",
+					Then:      []Field{{Name: "state", Type: FieldTypeText}},
+					Else:      []Field{{Name: "region", Type: FieldTypeText}},
+				}},
+			},
+			values: url.Values{"country": {"US"}, "state": {"CA"}},
+			want:   map[string]any{"country": "US", "state": "CA"},
+		},
+		{
+			name:   "hidden const field",
+			fields: []Field{{Name: "version", Type: FieldTypeHidden, DataType: DataTypeInteger, Value: 2}},
+			values: url.Values{"version": {"2"}},
+			want:   map[string]any{"version": int64(2)},
+		},
+		{
+			name:   "variant with discriminator",
+			fields: []Field{paymentField()},
+			values: url.Values{"payment": {"bank"}, "payment.iban": {"SE35"}, "payment.number": {"ignored"}},
+			want:   map[string]any{"payment": map[string]any{"method": "bank", "iban": "SE35"}},
+		},
+		{
+			name: "scalar variant",
+			fields: []Field{{Name: "limit", Type: FieldTypeVariant, Variants: []Variant{
+				{Label: "Unlimited", Value: 0, Scalar: true, Fields: []Field{{Name: "value", Type: FieldTypeHidden, Value: "unlimited"}}},
+				{Label: "Count", Value: 1, Scalar: true, Fields: []Field{{Name: "value", Type: FieldTypeNumber, DataType: DataTypeInteger}}},
+			}}},
+			values: url.Values{"limit": {"1"}, "limit.value": {"10"}},
+			want:   map[string]any{"limit": int64(10)},
+		},
+		{
+			name: "object variant without discriminator",
+			fields: []Field{{Name: "contact", Type: FieldTypeVariant, Variants: []Variant{
+				{Label: "Email", Value: 0, Fields: []Field{{Name: "email", Type: FieldTypeEmail}}},
+				{Label: "Phone", Value: 1, Fields: []Field{{Name: "phone", Type: FieldTypeText}}},
+			}}},
+			values: url.Values{"contact": {"1"}, "contact.phone": {"555"}, "contact.email": {"a@b.c"}},
+			want:   map[string]any{"contact": map[string]any{"phone": "555"}},
+		},
+		{
+			name:   "variant without selection",
+			fields: []Field{paymentField()},
+			values: url.Values{"payment.iban": {"SE35"}},
+			want:   map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &Form{Fields: tt.fields}
+			got, err := form.DecodeValues(tt.values)
+			if tt.wantErrs != nil {
+				if !reflect.DeepEqual(err, tt.wantErrs) {
+					t.Errorf("Form.DecodeValues() error = %#v, want %#v", err, tt.wantErrs)
+				}
+			} else if err != nil {
+				t.Fatalf("Form.DecodeValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Form.DecodeValues() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForm_DecodeValues_NilForm(t *testing.T) {
+	var form *Form
+	if _, err := form.DecodeValues(url.Values{}); err == nil {
+		t.Error("Form.DecodeValues() on nil form should return an error")
+	}
+}
+
+func TestForm_DecodeVariants(t *testing.T) {
+	form := &Form{Fields: []Field{
+		paymentField(),
+		{Name: "limits", Type: FieldTypeArray, Fields: []Field{{Name: "item", Type: FieldTypeVariant, Variants: []Variant{
+			{Label: "Name", Value: 0, Scalar: true, Fields: []Field{{Name: "value", Type: FieldTypeText}}},
+			{Label: "Count", Value: 1, Scalar: true, Fields: []Field{{Name: "value", Type: FieldTypeNumber, DataType: DataTypeInteger}}},
+		}}}},
+	}}
+
+	tests := []struct {
+		name   string
+		values url.Values
+		want   SelectedVariants
+	}{
+		{
+			name:   "submitted selectors",
+			values: url.Values{"payment": {"bank"}, "payment.iban": {"SE35"}, "limits[0]": {"1"}, "limits[0].value": {"abc"}},
+			want:   SelectedVariants{"payment": 1, "limits[0]": 1},
+		},
+		{
+			name:   "variants without a value",
+			values: url.Values{"payment": {"bank"}, "limits[0]": {"1"}},
+			want:   SelectedVariants{"payment": 1},
+		},
+		{
+			name: "removed item",
+			values: url.Values{
+				"limits[0]": {"0"}, "limits[0].value": {"a"}, "limits[1]": {"1"}, "limits[1].value": {"2"},
+				RemoveItemParam: {"limits[0]"},
+			},
+			want: SelectedVariants{"limits[0]": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := form.DecodeVariants(tt.values); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Form.DecodeVariants() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForm_DecodeJSON(t *testing.T) {
+	form := &Form{
+		Fields: []Field{
+			{Name: "name", Type: FieldTypeText},
+			{Name: "age", Type: FieldTypeNumber, DataType: DataTypeInteger},
+			{Name: "admin", Type: FieldTypeCheckbox},
+			{Name: "limit", Type: FieldTypeVariant, Variants: []Variant{
+				{Label: "Count", Value: 0, Scalar: true, Fields: []Field{{Name: "value", Type: FieldTypeNumber, DataType: DataTypeInteger}}},
+			}},
+		},
+	}
+
+	got, err := form.DecodeJSON(url.Values{"name": {"Bob"}, "age": {"30"}, "limit": {"0"}, "limit.value": {"5"}})
+	if err != nil {
+		t.Fatalf("Form.DecodeJSON() error = %v", err)
+	}
+
+	want := `{"admin":false,"age":30,"limit":5,"name":"Bob"}`
+	if string(got) != want {
+		t.Errorf("Form.DecodeJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestForm_DecodeRequest(t *testing.T) {
+	form := &Form{
+		Fields: []Field{
+			{Name: "name", Type: FieldTypeText},
+			{Name: "avatar", Type: FieldTypeFile},
+		},
+	}
+
+	t.Run("url encoded", func(t *testing.T) {
+		body := strings.NewReader(url.Values{"name": {"Alice"}}.Encode())
+		req := httptest.NewRequest(http.MethodPost, "/", body)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		got, err := form.DecodeRequest(req)
+		if err != nil {
+			t.Fatalf("Form.DecodeRequest() error = %v", err)
+		}
+		if got["name"] != "Alice" {
+			t.Errorf("Form.DecodeRequest() name = %v, want Alice", got["name"])
+		}
+	})
+
+	t.Run("multipart with file", func(t *testing.T) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		if err := writer.WriteField("name", "Alice"); err != nil {
+			t.Fatal(err)
+		}
+		part, err := writer.CreateFormFile("avatar", "avatar.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := part.Write([]byte("hello")); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		got, err := form.DecodeRequest(req)
+		if err != nil {
+			t.Fatalf("Form.DecodeRequest() error = %v", err)
+		}
+		encoded, _ := json.Marshal(got)
+		if want := `{"avatar":"aGVsbG8=","name":"Alice"}`; string(encoded) != want {
+			t.Errorf("Form.DecodeRequest() = %s, want %s", encoded, want)
+		}
+	})
+}
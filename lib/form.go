@@ -27,8 +27,21 @@ const (
 	FieldTypeRadio    FieldType = "radio"
 	FieldTypeFile     FieldType = "file"
 	FieldTypeHidden   FieldType = "hidden"
-	FieldTypeObject   FieldType = "object" // For nested objects
-	FieldTypeArray    FieldType = "array"  // For arrays
+	FieldTypeObject   FieldType = "object"  // For nested objects
+	FieldTypeArray    FieldType = "array"   // For arrays
+	FieldTypeVariant  FieldType = "variant" // For one of several alternative field groups (oneOf/anyOf)
+)
+
+// DataType represents the JSON type of the value a field submits
+type DataType string
+
+const (
+	DataTypeString  DataType = "string"
+	DataTypeNumber  DataType = "number"
+	DataTypeInteger DataType = "integer"
+	DataTypeBoolean DataType = "boolean"
+	DataTypeArray   DataType = "array"
+	DataTypeObject  DataType = "object"
 )
 
 // Option represents an option for select, radio, or checkbox fields
@@ -39,51 +52,88 @@ type Option struct {
 
 // Validation represents validation rules for a form field
 type Validation struct {
-	Required     bool     `json:"required,omitempty"`
-	MinLength    *int     `json:"minLength,omitempty"`
-	MaxLength    *int     `json:"maxLength,omitempty"`
-	Min          *float64 `json:"min,omitempty"`
-	Max          *float64 `json:"max,omitempty"`
-	Pattern      string   `json:"pattern,omitempty"`
-	PatternError string   `json:"patternError,omitempty"`
-	Step         *float64 `json:"step,omitempty"`
-	MinItems     *int     `json:"minItems,omitempty"`
-	MaxItems     *int     `json:"maxItems,omitempty"`
+	Required     bool       `json:"required,omitempty"`
+	MinLength    *int       `json:"minLength,omitempty"`
+	MaxLength    *int       `json:"maxLength,omitempty"`
+	Min          *float64   `json:"min,omitempty"`
+	Max          *float64   `json:"max,omitempty"`
+	ExclusiveMin bool       `json:"exclusiveMin,omitempty"` // Min itself is out of range
+	ExclusiveMax bool       `json:"exclusiveMax,omitempty"` // Max itself is out of range
+	Pattern      string     `json:"pattern,omitempty"`      // Matched anywhere in the value unless anchored, as in JSON Schema
+	PatternError string     `json:"patternError,omitempty"`
+	Step         *float64   `json:"step,omitempty"`
+	MinItems     *int       `json:"minItems,omitempty"`
+	MaxItems     *int       `json:"maxItems,omitempty"`
+	RequiredWhen *Condition `json:"requiredWhen,omitempty"` // Field is required when this condition on its siblings holds
+}
+
+// Variant represents one alternative of a variant field
+type Variant struct {
+	Label    string  `json:"label"`
+	Value    any     `json:"value"`              // Value submitted by the variant selector, the discriminator value if any
+	Fields   []Field `json:"fields,omitempty"`   // Fields shown when the variant is selected
+	Scalar   bool    `json:"scalar,omitempty"`   // The variant's value is its single field's value rather than an object
+	Selected bool    `json:"selected,omitempty"` // Set by Populate on the variant the field's value was submitted for
 }
 
 // ConditionalField represents a conditional field (if/then/else logic)
 type ConditionalField struct {
-	Condition string  `json:"condition"`      // Field name that triggers this condition
-	Value     any     `json:"value"`          // Value that triggers this condition
-	Then      []Field `json:"then"`           // Fields to show when condition is met
-	Else      []Field `json:"else,omitempty"` // Fields to show when condition is not met
+	Condition string     `json:"condition,omitempty"` // Field name that triggers this condition
+	Value     any        `json:"value,omitempty"`     // Value that triggers this condition
+	When      *Condition `json:"when,omitempty"`      // Condition used instead of Condition and Value if set
+	Then      []Field    `json:"then"`                // Fields to show when condition is met
+	Else      []Field    `json:"else,omitempty"`      // Fields to show when condition is not met
 }
 
 // Field represents a single form field
 type Field struct {
-	Name        string            `json:"name"`
-	Type        FieldType         `json:"type"`
-	Label       string            `json:"label,omitempty"`
-	Description string            `json:"description,omitempty"`
-	Placeholder string            `json:"placeholder,omitempty"`
-	Default     any               `json:"default,omitempty"`
-	Value       any               `json:"value,omitempty"`
-	Options     []Option          `json:"options,omitempty"`
-	Validation  *Validation       `json:"validation,omitempty"`
-	ReadOnly    bool              `json:"readOnly,omitempty"`
-	Deprecated  bool              `json:"deprecated,omitempty"`
-	Fields      []Field           `json:"fields,omitempty"` // For object/array types
-	Conditional *ConditionalField `json:"conditional,omitempty"`
-	HelpText    string            `json:"helpText,omitempty"`
+	Name          string             `json:"name"`
+	Type          FieldType          `json:"type"`
+	DataType      DataType           `json:"dataType,omitempty"` // JSON type of the submitted value, derived from Type if empty
+	Label         string             `json:"label,omitempty"`
+	Description   string             `json:"description,omitempty"`
+	Placeholder   string             `json:"placeholder,omitempty"`
+	Default       any                `json:"default,omitempty"`
+	Value         any                `json:"value,omitempty"`
+	Options       []Option           `json:"options,omitempty"`
+	Validation    *Validation        `json:"validation,omitempty"`
+	ReadOnly      bool               `json:"readOnly,omitempty"`
+	Deprecated    bool               `json:"deprecated,omitempty"`
+	Fields        []Field            `json:"fields,omitempty"`        // For object/array types
+	Items         []Field            `json:"items,omitempty"`         // Populated copies of an array's item field, one per item
+	Variants      []Variant          `json:"variants,omitempty"`      // For variant types
+	Discriminator string             `json:"discriminator,omitempty"` // Property holding the selected variant's Value
+	Conditional   *ConditionalField  `json:"conditional,omitempty"`
+	Conditionals  []ConditionalField `json:"conditionals,omitempty"` // Further conditionals, shown after Conditional
+	HelpText      string             `json:"helpText,omitempty"`
+	Error         string             `json:"error,omitempty"` // Error message shown next to the field
+}
+
+// AllConditionals returns the field's Conditional, if any, followed by its further Conditionals
+func (f *Field) AllConditionals() []*ConditionalField {
+	var conditionals []*ConditionalField
+	if f.Conditional != nil {
+		conditionals = append(conditionals, f.Conditional)
+	}
+	for i := range f.Conditionals {
+		conditionals = append(conditionals, &f.Conditionals[i])
+	}
+	return conditionals
 }
 
+// MethodParam is the name of the parameter a form whose Method is not GET or POST, which
+// HTML forms cannot be submitted with, carries its method in when submitted with POST
+const MethodParam = "_method"
+
 // Form represents a complete HTML form structure
 type Form struct {
-	Title       string  `json:"title,omitempty"`
-	Description string  `json:"description,omitempty"`
-	Action      string  `json:"action,omitempty"`
-	Method      string  `json:"method,omitempty"`
-	Fields      []Field `json:"fields"`
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Action      string       `json:"action,omitempty"`
+	Method      string       `json:"method,omitempty"`
+	Naming      NamingScheme `json:"naming,omitempty"` // How nested field names are qualified, dotted if empty
+	Fields      []Field      `json:"fields"`
+	Error       string       `json:"error,omitempty"` // Error message not tied to a single field
 }
 
 // Validate validates the form structure to ensure it's in a valid state
@@ -109,12 +159,32 @@ func (f *Form) Validate() error {
 		}
 	}
 
+	if err := validateNamingScheme(f.Naming); err != nil {
+		return err
+	}
+
+	// Validate all top-level fields
+	return f.validateFields(f.Fields, "fields")
+}
+
+// validateFields validates a list of sibling fields, in a scope of their own, at paths
+// prefix[i]. Conditions on which fields are required may test any of the siblings, so
+// they are validated once every sibling is known.
+func (f *Form) validateFields(fields []Field, prefix string) error {
 	// Track field names to ensure uniqueness
 	fieldNames := make(map[string]bool)
+	for i, field := range fields {
+		if err := f.validateField(&field, fieldNames, fmt.Sprintf("%s[%d]", prefix, i)); err != nil {
+			return err
+		}
+	}
 
-	// Validate all top-level fields
-	for i, field := range f.Fields {
-		if err := f.validateField(&field, fieldNames, fmt.Sprintf("fields[%d]", i)); err != nil {
+	for i, field := range fields {
+		if field.Validation == nil || field.Validation.RequiredWhen == nil {
+			continue
+		}
+		path := fmt.Sprintf("%s[%d].validation.requiredWhen", prefix, i)
+		if err := validateCondition(field.Validation.RequiredWhen, fieldNames, path); err != nil {
 			return err
 		}
 	}
@@ -146,6 +216,11 @@ func (f *Form) validateField(field *Field, parentFieldNames map[string]bool, pat
 		return err
 	}
 
+	// Validate data type
+	if err := validateDataType(field.DataType, path); err != nil {
+		return err
+	}
+
 	// Validate field type-specific constraints
 	if err := f.validateFieldTypeConstraints(field, path); err != nil {
 		return err
@@ -160,10 +235,21 @@ func (f *Form) validateField(field *Field, parentFieldNames map[string]bool, pat
 
 	// Validate conditional fields
 	if field.Conditional != nil {
-		if err := f.validateConditionalField(field.Conditional, parentFieldNames, path); err != nil {
+		if err := f.validateConditionalField(field.Conditional, parentFieldNames, path+".conditional"); err != nil {
 			return err
 		}
 	}
+	for i := range field.Conditionals {
+		conditionalPath := fmt.Sprintf("%s.conditionals[%d]", path, i)
+		if err := f.validateConditionalField(&field.Conditionals[i], parentFieldNames, conditionalPath); err != nil {
+			return err
+		}
+	}
+
+	// Validate variants
+	if err := f.validateVariants(field, path); err != nil {
+		return err
+	}
 
 	// Validate nested fields (for objects and arrays)
 	if len(field.Fields) > 0 {
@@ -171,14 +257,55 @@ func (f *Form) validateField(field *Field, parentFieldNames map[string]bool, pat
 			return fmt.Errorf("%s: fields with nested Fields must have type 'object' or 'array', got '%s'", path, field.Type)
 		}
 
-		// Create a new scope for nested field names
-		nestedFieldNames := make(map[string]bool)
-		for i, nestedField := range field.Fields {
-			nestedPath := fmt.Sprintf("%s.fields[%d]", path, i)
-			if err := f.validateField(&nestedField, nestedFieldNames, nestedPath); err != nil {
-				return err
+		// Nested fields have a scope of their own
+		if err := f.validateFields(field.Fields, path+".fields"); err != nil {
+			return err
+		}
+	}
+
+	// Validate populated array items
+	if len(field.Items) > 0 && field.Type != FieldTypeArray {
+		return fmt.Errorf("%s: only fields of type 'array' can have items, got '%s'", path, field.Type)
+	}
+
+	return nil
+}
+
+// validateVariants validates the alternatives of a variant field
+func (f *Form) validateVariants(field *Field, path string) error {
+	if field.Type != FieldTypeVariant {
+		if len(field.Variants) > 0 {
+			return fmt.Errorf("%s: only fields of type 'variant' can have variants, got '%s'", path, field.Type)
+		}
+		return nil
+	}
+
+	if len(field.Variants) == 0 {
+		return fmt.Errorf("%s: field type '%s' requires at least one variant", path, field.Type)
+	}
+
+	variantValues := make(map[string]bool)
+	for i, variant := range field.Variants {
+		variantPath := fmt.Sprintf("%s.variants[%d]", path, i)
+
+		variantValue := fmt.Sprintf("%v", variant.Value)
+		if variantValues[variantValue] {
+			return fmt.Errorf("%s: duplicate variant value '%s'", variantPath, variantValue)
+		}
+		variantValues[variantValue] = true
+
+		if variant.Scalar && len(variant.Fields) != 1 {
+			return fmt.Errorf("%s: scalar variant must have exactly one field, got %d", variantPath, len(variant.Fields))
+		}
+
+		for j, variantField := range variant.Fields {
+			if variantField.Name != "" && variantField.Name == field.Discriminator {
+				return fmt.Errorf("%s.fields[%d]: field name '%s' conflicts with the discriminator", variantPath, j, variantField.Name)
 			}
 		}
+		if err := f.validateFields(variant.Fields, variantPath+".fields"); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -232,6 +359,7 @@ func validateFieldType(fieldType FieldType, path string) error {
 		FieldTypeHidden:   true,
 		FieldTypeObject:   true,
 		FieldTypeArray:    true,
+		FieldTypeVariant:  true,
 	}
 
 	if !validTypes[fieldType] {
@@ -241,6 +369,16 @@ func validateFieldType(fieldType FieldType, path string) error {
 	return nil
 }
 
+// validateDataType validates that the data type, if set, is a known JSON type
+func validateDataType(dataType DataType, path string) error {
+	switch dataType {
+	case "", DataTypeString, DataTypeNumber, DataTypeInteger,
+		DataTypeBoolean, DataTypeArray, DataTypeObject:
+		return nil
+	}
+	return fmt.Errorf("%s: invalid data type '%s'", path, dataType)
+}
+
 // validateFieldTypeConstraints validates type-specific constraints
 func (f *Form) validateFieldTypeConstraints(field *Field, path string) error {
 	// Select and Radio fields must have options
@@ -405,43 +543,34 @@ func validateValidationRules(validation *Validation, fieldType FieldType, path s
 	return nil
 }
 
-// validateConditionalField validates conditional field logic
+// validateConditionalField validates conditional field logic, of the conditional at path
 func (f *Form) validateConditionalField(conditional *ConditionalField, parentFieldNames map[string]bool, path string) error {
 	if conditional == nil {
 		return nil
 	}
 
-	// Validate condition field name exists
-	if conditional.Condition == "" {
-		return fmt.Errorf("%s: conditional field must specify a condition field name", path)
-	}
+	if conditional.When != nil {
+		// Validate the condition and the fields it references
+		if err := validateCondition(conditional.When, parentFieldNames, path+".when"); err != nil {
+			return err
+		}
+	} else {
+		// Validate condition field name exists
+		if conditional.Condition == "" {
+			return fmt.Errorf("%s: conditional field must specify a condition field name", path)
+		}
 
-	// Check that the condition field exists in the parent scope
-	if !parentFieldNames[conditional.Condition] {
-		return fmt.Errorf("%s: conditional field references non-existent field '%s'", path, conditional.Condition)
+		// Check that the condition field exists in the parent scope
+		if !parentFieldNames[conditional.Condition] {
+			return fmt.Errorf("%s: conditional field references non-existent field '%s'", path, conditional.Condition)
+		}
 	}
 
 	// Validate Then fields
-	if len(conditional.Then) > 0 {
-		thenFieldNames := make(map[string]bool)
-		for i, field := range conditional.Then {
-			thenPath := fmt.Sprintf("%s.conditional.then[%d]", path, i)
-			if err := f.validateField(&field, thenFieldNames, thenPath); err != nil {
-				return err
-			}
-		}
+	if err := f.validateFields(conditional.Then, path+".then"); err != nil {
+		return err
 	}
 
 	// Validate Else fields
-	if len(conditional.Else) > 0 {
-		elseFieldNames := make(map[string]bool)
-		for i, field := range conditional.Else {
-			elsePath := fmt.Sprintf("%s.conditional.else[%d]", path, i)
-			if err := f.validateField(&field, elseFieldNames, elsePath); err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
+	return f.validateFields(conditional.Else, path+".else")
 }
@@ -26,6 +26,23 @@ func TestForm_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "form must have at least one field",
 		},
+		{
+			name: "invalid naming scheme",
+			form: &Form{
+				Naming: NamingScheme("slashed"),
+				Fields: []Field{{Name: "username", Type: FieldTypeText}},
+			},
+			wantErr: true,
+			errMsg:  "invalid naming scheme",
+		},
+		{
+			name: "items on non-array field",
+			form: &Form{
+				Fields: []Field{{Name: "username", Type: FieldTypeText, Items: []Field{{Name: "item", Type: FieldTypeText}}}},
+			},
+			wantErr: true,
+			errMsg:  "only fields of type 'array' can have items",
+		},
 		{
 			name: "valid form with single field",
 			form: &Form{
@@ -702,6 +719,149 @@ func TestForm_Validate_ConditionalFields(t *testing.T) {
 			wantErr: true,
 			errMsg:  "duplicate field name",
 		},
+		{
+			name: "valid compound condition",
+			form: &Form{
+				Fields: []Field{
+					{Name: "age", Type: FieldTypeNumber},
+					{
+						Name: "country",
+						Type: FieldTypeText,
+						Conditional: &ConditionalField{
+							When: &Condition{Operator: ConditionOr, Conditions: []Condition{
+								{Operator: ConditionRange, Field: "age", Min: floatPtr(18)},
+								{Operator: ConditionNot, Conditions: []Condition{{Operator: ConditionPresent, Field: "country"}}},
+							}},
+							Then: []Field{{Name: "then_field", Type: FieldTypeText}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "condition references non-existent field",
+			form: &Form{
+				Fields: []Field{
+					{
+						Name: "field1",
+						Type: FieldTypeText,
+						Conditional: &ConditionalField{
+							When: &Condition{Operator: ConditionAnd, Conditions: []Condition{
+								{Operator: ConditionEquals, Field: "field1", Value: "a"},
+								{Operator: ConditionPresent, Field: "nonexistent"},
+							}},
+							Then: []Field{{Name: "then_field", Type: FieldTypeText}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "conditional.when.conditions[1]: condition references non-existent field 'nonexistent'",
+		},
+		{
+			name: "further conditional references non-existent field",
+			form: &Form{
+				Fields: []Field{
+					{
+						Name: "field1",
+						Type: FieldTypeText,
+						Conditionals: []ConditionalField{{
+							When: &Condition{Operator: ConditionPresent, Field: "nonexistent"},
+							Then: []Field{{Name: "then_field", Type: FieldTypeText}},
+						}},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "fields[0].conditionals[0].when: condition references non-existent field 'nonexistent'",
+		},
+		{
+			name: "not condition with several conditions",
+			form: &Form{
+				Fields: []Field{
+					{
+						Name: "field1",
+						Type: FieldTypeText,
+						Conditional: &ConditionalField{
+							When: &Condition{Operator: ConditionNot, Conditions: []Condition{
+								{Operator: ConditionPresent, Field: "field1"},
+								{Operator: ConditionEquals, Field: "field1", Value: "a"},
+							}},
+							Then: []Field{{Name: "then_field", Type: FieldTypeText}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "requires exactly one condition",
+		},
+		{
+			name: "range condition without bounds",
+			form: &Form{
+				Fields: []Field{
+					{
+						Name: "field1",
+						Type: FieldTypeNumber,
+						Conditional: &ConditionalField{
+							When: &Condition{Operator: ConditionRange, Field: "field1"},
+							Then: []Field{{Name: "then_field", Type: FieldTypeText}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "requires a min or max",
+		},
+		{
+			name: "invalid condition operator",
+			form: &Form{
+				Fields: []Field{
+					{
+						Name: "field1",
+						Type: FieldTypeText,
+						Conditional: &ConditionalField{
+							When: &Condition{Operator: "matches", Field: "field1"},
+							Then: []Field{{Name: "then_field", Type: FieldTypeText}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid condition operator 'matches'",
+		},
+		{
+			name: "required when a later sibling is present",
+			form: &Form{
+				Fields: []Field{
+					{
+						Name: "billing_address",
+						Type: FieldTypeText,
+						Validation: &Validation{
+							RequiredWhen: &Condition{Operator: ConditionPresent, Field: "credit_card"},
+						},
+					},
+					{Name: "credit_card", Type: FieldTypeText},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "required when references non-existent field",
+			form: &Form{
+				Fields: []Field{
+					{
+						Name: "billing_address",
+						Type: FieldTypeText,
+						Validation: &Validation{
+							RequiredWhen: &Condition{Operator: ConditionPresent, Field: "credit_card"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "fields[0].validation.requiredWhen: condition references non-existent field 'credit_card'",
+		},
 	}
 
 	for _, tt := range tests {
@@ -720,6 +880,84 @@ func TestForm_Validate_ConditionalFields(t *testing.T) {
 	}
 }
 
+func TestForm_Validate_Variants(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   Field
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:  "valid variant field",
+			field: paymentField(),
+		},
+		{
+			name:    "variant field without variants",
+			field:   Field{Name: "payment", Type: FieldTypeVariant},
+			wantErr: true,
+			errMsg:  "requires at least one variant",
+		},
+		{
+			name: "variants on non-variant field",
+			field: Field{Name: "payment", Type: FieldTypeText, Variants: []Variant{
+				{Label: "Card", Value: "card"},
+			}},
+			wantErr: true,
+			errMsg:  "only fields of type 'variant' can have variants",
+		},
+		{
+			name: "duplicate variant values",
+			field: Field{Name: "payment", Type: FieldTypeVariant, Variants: []Variant{
+				{Label: "Card", Value: "card"},
+				{Label: "Credit card", Value: "card"},
+			}},
+			wantErr: true,
+			errMsg:  "duplicate variant value 'card'",
+		},
+		{
+			name: "scalar variant with several fields",
+			field: Field{Name: "limit", Type: FieldTypeVariant, Variants: []Variant{
+				{Label: "Count", Value: 0, Scalar: true, Fields: []Field{
+					{Name: "value", Type: FieldTypeNumber},
+					{Name: "unit", Type: FieldTypeText},
+				}},
+			}},
+			wantErr: true,
+			errMsg:  "scalar variant must have exactly one field",
+		},
+		{
+			name: "variant field named like the discriminator",
+			field: Field{Name: "payment", Type: FieldTypeVariant, Discriminator: "method", Variants: []Variant{
+				{Label: "Card", Value: "card", Fields: []Field{{Name: "method", Type: FieldTypeText}}},
+			}},
+			wantErr: true,
+			errMsg:  "conflicts with the discriminator",
+		},
+		{
+			name: "invalid variant field",
+			field: Field{Name: "payment", Type: FieldTypeVariant, Variants: []Variant{
+				{Label: "Card", Value: "card", Fields: []Field{{Name: "number", Type: FieldTypeSelect}}},
+			}},
+			wantErr: true,
+			errMsg:  "fields[0].variants[0].fields[0]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &Form{Fields: []Field{tt.field}}
+			err := form.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Form.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !contains(err.Error(), tt.errMsg) {
+				t.Errorf("Form.Validate() error message = %v, want to contain %v", err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
 func TestForm_Validate_NestedFields(t *testing.T) {
 	tests := []struct {
 		name    string
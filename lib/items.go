@@ -0,0 +1,36 @@
+package lib
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Names of the submit buttons that edit the items of an array field when a form is
+// submitted without JavaScript. Their value is the qualified name of the array field
+// to add an item to, or of the array item to remove.
+const (
+	AddItemParam    = "_add-item"
+	RemoveItemParam = "_remove-item"
+)
+
+// EditItems handles a submission made by pressing an add or remove item button, reporting
+// whether one was pressed. It decodes the submitted values like DecodeValues, with the
+// removed item left out or an empty (nil) item appended to the array, so the form can be
+// re-rendered with the edited items instead of processing the submission.
+func (f *Form) EditItems(values url.Values) (map[string]any, bool, error) {
+	if f == nil {
+		return nil, false, fmt.Errorf("form cannot be nil")
+	}
+
+	add, remove := values.Get(AddItemParam), values.Get(RemoveItemParam)
+	if add == "" && remove == "" {
+		return nil, false, nil
+	}
+
+	d := &decoder{values: values, naming: f.Naming, addItem: add, removeItem: remove}
+	decoded, err := d.decodeFields(f.Fields, "", "")
+	if err != nil {
+		return nil, true, err
+	}
+	return decoded, true, nil
+}
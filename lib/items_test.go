@@ -0,0 +1,62 @@
+package lib
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestForm_EditItems(t *testing.T) {
+	form := &Form{Fields: []Field{
+		{Name: "title", Type: FieldTypeText},
+		{Name: "tags", Type: FieldTypeArray, Fields: []Field{{Name: "item", Type: FieldTypeText}}},
+		{Name: "lines", Type: FieldTypeArray, Fields: []Field{{Name: "item", Type: FieldTypeObject, Fields: []Field{
+			{Name: "sku", Type: FieldTypeText},
+		}}}},
+	}}
+
+	tests := []struct {
+		name       string
+		values     url.Values
+		want       map[string]any
+		wantEdited bool
+	}{
+		{
+			name:   "no button pressed",
+			values: url.Values{"title": {"Order"}, "tags[0]": {"a"}},
+		},
+		{
+			name:       "add item",
+			values:     url.Values{"tags[0]": {"a"}, AddItemParam: {"tags"}},
+			want:       map[string]any{"tags": []any{"a", nil}},
+			wantEdited: true,
+		},
+		{
+			name:       "add item to empty array",
+			values:     url.Values{"title": {"Order"}, AddItemParam: {"lines"}},
+			want:       map[string]any{"title": "Order", "lines": []any{nil}},
+			wantEdited: true,
+		},
+		{
+			name:       "remove item",
+			values:     url.Values{"lines[0].sku": {"a"}, "lines[1].sku": {"b"}, "lines[2].sku": {"c"}, RemoveItemParam: {"lines[1]"}},
+			want:       map[string]any{"lines": []any{map[string]any{"sku": "a"}, map[string]any{"sku": "c"}}},
+			wantEdited: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, edited, err := form.EditItems(tt.values)
+			if err != nil {
+				t.Fatalf("Form.EditItems() error = %v", err)
+			}
+			if edited != tt.wantEdited {
+				t.Errorf("Form.EditItems() edited = %v, want %v", edited, tt.wantEdited)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Form.EditItems() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package lib
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultWords are the words HumanizeLabel writes other than in lower case, keyed by their
+// lower-case spelling
+var DefaultWords = map[string]string{
+	"api":   "API",
+	"css":   "CSS",
+	"csv":   "CSV",
+	"dns":   "DNS",
+	"faq":   "FAQ",
+	"html":  "HTML",
+	"http":  "HTTP",
+	"https": "HTTPS",
+	"id":    "ID",
+	"ip":    "IP",
+	"json":  "JSON",
+	"pdf":   "PDF",
+	"sku":   "SKU",
+	"sms":   "SMS",
+	"sql":   "SQL",
+	"uri":   "URI",
+	"url":   "URL",
+	"uuid":  "UUID",
+	"xml":   "XML",
+}
+
+// Labeler generates human-readable labels from the names of fields and values, for those
+// their schema gives no title
+type Labeler struct {
+	// Words maps lower-case words to how labels write them, such as "id" to "ID" or "dob" to
+	// "date of birth". Other words are lower-cased, except acronyms the name writes in upper
+	// case. Copy DefaultWords into it to extend the defaults rather than replace them.
+	Words map[string]string
+}
+
+// HumanizeLabel returns the label of a name with the DefaultWords, like Labeler.Label
+func HumanizeLabel(name string) string {
+	return Labeler{Words: DefaultWords}.Label(name)
+}
+
+// ValueLabels returns the labels of the values of an enum, or of the discriminator values of
+// variants, generating the labels of values that are identifiers with label. Other values,
+// such as "+", "1-5" or "image/png", are labeled as written, and so are values whose label
+// would be empty or the same as the label of another value.
+func ValueLabels(values []any, label func(string) string) []string {
+	labels := make([]string, len(values))
+	count := make(map[string]int)
+	for i, value := range values {
+		written := fmt.Sprintf("%v", value)
+		labels[i] = written
+		if s, ok := value.(string); ok && isIdentifier(s) {
+			if generated := label(s); generated != "" {
+				labels[i] = generated
+			}
+		}
+		count[labels[i]]++
+	}
+
+	for i, value := range values {
+		if count[labels[i]] > 1 {
+			labels[i] = fmt.Sprintf("%v", value)
+		}
+	}
+	return labels
+}
+
+// isIdentifier reports whether s is a name made of letters and digits, possibly separated by
+// underscores or hyphens, starting with a letter
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		switch {
+		case unicode.IsLetter(r):
+		case i > 0 && (unicode.IsDigit(r) || r == '_' || r == '-'):
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Label returns a label for a name written in snake_case, kebab-case, camelCase,
+// PascalCase or SCREAMING_CASE: its words in sentence case, such as "First name" for
+// first_name and "Date of birth" for dateOfBirth. Acronyms are kept, so userID becomes
+// "User ID". Names containing spaces are taken to be labels already and are returned as is.
+func (l Labeler) Label(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.Contains(name, " ") {
+		return name
+	}
+
+	words := splitWords(name)
+	shouting := len(words) > 1 && !strings.ContainsFunc(name, unicode.IsLower)
+	for i, word := range words {
+		words[i] = l.word(word, shouting)
+	}
+	return upperFirst(strings.Join(words, " "))
+}
+
+// word returns how a label writes a single word of a name. Words of names written entirely
+// in upper case are not taken to be acronyms.
+func (l Labeler) word(word string, shouting bool) string {
+	lower := strings.ToLower(word)
+	if spelled, ok := l.Words[lower]; ok {
+		return spelled
+	}
+	if singular, ok := strings.CutSuffix(lower, "s"); ok {
+		if spelled, ok := l.Words[singular]; ok {
+			return spelled + "s"
+		}
+	}
+	if !shouting && isAcronym(word) {
+		return word
+	}
+	return lower
+}
+
+// splitWords splits a name into words at separators and changes of case: "HTTPServer" and
+// "http_server" both split into "HTTP"/"http" and "Server"/"server". A trailing s after an
+// acronym stays part of it, so "userIDs" splits into "user" and "IDs".
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+
+		prev := runes[i-1]
+		switch {
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			// fooBar, line2Total
+		case unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) &&
+			!isPluralEnd(runes, i+1):
+			// HTTPServer: the last upper-case letter starts the next word
+		default:
+			continue
+		}
+		words = append(words, string(runes[start:i]))
+		start = i
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// isPluralEnd reports whether the rune at i is an s ending a word, as in "IDs"
+func isPluralEnd(runes []rune, i int) bool {
+	if runes[i] != 's' {
+		return false
+	}
+	return i+1 == len(runes) || !unicode.IsLower(runes[i+1])
+}
+
+// isAcronym reports whether a word is written in upper case, possibly as a plural: "ID",
+// "URLs". Single letters are not acronyms.
+func isAcronym(word string) bool {
+	word = strings.TrimSuffix(word, "s")
+	return utf8.RuneCountInString(word) > 1 && !strings.ContainsFunc(word, unicode.IsLower)
+}
+
+// upperFirst upper-cases the first letter of s
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
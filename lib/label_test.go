@@ -0,0 +1,98 @@
+package lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHumanizeLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "first_name", want: "First name"},
+		{name: "dateOfBirth", want: "Date of birth"},
+		{name: "DateOfBirth", want: "Date of birth"},
+		{name: "date-of-birth", want: "Date of birth"},
+		{name: "userID", want: "User ID"},
+		{name: "user_id", want: "User ID"},
+		{name: "userIDs", want: "User IDs"},
+		{name: "user_ids", want: "User IDs"},
+		{name: "HTTPServer", want: "HTTP server"},
+		{name: "homepageURL", want: "Homepage URL"},
+		{name: "URLsCount", want: "URLs count"},
+		{name: "vatNumberEU", want: "Vat number EU"},
+		{name: "ROLE_ADMIN", want: "Role admin"},
+		{name: "USD", want: "USD"},
+		{name: "line2Total", want: "Line2 total"},
+		{name: "address.street", want: "Address street"},
+		{name: "item", want: "Item"},
+		{name: "x", want: "X"},
+		{name: "émailAdresse", want: "Émail adresse"},
+		{name: "New York", want: "New York"},
+		{name: "__", want: ""},
+		{name: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HumanizeLabel(tt.name); got != tt.want {
+				t.Errorf("HumanizeLabel(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabeler_Words(t *testing.T) {
+	labeler := Labeler{Words: map[string]string{"dob": "date of birth", "vat": "VAT", "id": "Id"}}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "dob", want: "Date of birth"},
+		{name: "customer_dob", want: "Customer date of birth"},
+		{name: "vatNumber", want: "VAT number"},
+		{name: "user_id", want: "User Id"},
+		{name: "homepage_url", want: "Homepage url"}, // Not a word of this dictionary
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := labeler.Label(tt.name); got != tt.want {
+				t.Errorf("Labeler.Label(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValueLabels(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []any
+		want   []string
+	}{
+		{name: "identifiers", values: []any{"in_progress", "done", "userID"}, want: []string{"In progress", "Done", "User ID"}},
+		{name: "symbols", values: []any{"+", "-", "*", "/"}, want: []string{"+", "-", "*", "/"}},
+		{name: "ranges", values: []any{"1-5", "1_5", "6+"}, want: []string{"1-5", "1_5", "6+"}},
+		{name: "media types", values: []any{"image/png", "text/plain"}, want: []string{"image/png", "text/plain"}},
+		{name: "colliding labels", values: []any{"in-progress", "in_progress", "done"}, want: []string{"in-progress", "in_progress", "Done"}},
+		{name: "numbers and booleans", values: []any{1, 2.5, true}, want: []string{"1", "2.5", "true"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValueLabels(tt.values, HumanizeLabel)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValueLabels(%v) = %q, want %q", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValueLabels_EmptyLabel(t *testing.T) {
+	got := ValueLabels([]any{"a", "b"}, func(string) string { return "" })
+	if want := []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ValueLabels() = %q, want %q", got, want)
+	}
+}
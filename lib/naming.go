@@ -0,0 +1,69 @@
+package lib
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NamingScheme determines how the submitted names of nested fields are qualified with
+// the names of the fields they are nested in. Array items are always indexed.
+// The zero value qualifies names like NamingDotted.
+type NamingScheme string
+
+const (
+	NamingDotted  NamingScheme = "dotted"  // address.street, tags[0], lines[0].qty
+	NamingBracket NamingScheme = "bracket" // address[street], tags[0], lines[0][qty]
+)
+
+// Child returns the qualified name of a field named name nested in the field named parent
+func (n NamingScheme) Child(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	if n == NamingBracket {
+		return parent + "[" + name + "]"
+	}
+	return parent + "." + name
+}
+
+// Item returns the qualified name of the item at index of the array field named parent
+func (n NamingScheme) Item(parent string, index int) string {
+	return fmt.Sprintf("%s[%d]", parent, index)
+}
+
+// validateNamingScheme validates that a naming scheme is known
+func validateNamingScheme(naming NamingScheme) error {
+	switch naming {
+	case "", NamingDotted, NamingBracket:
+		return nil
+	}
+	return fmt.Errorf("invalid naming scheme: %s (must be one of: dotted, bracket)", naming)
+}
+
+// itemIndexes returns the indexes of the items of the array field named parent found
+// among the submitted names, in ascending order
+func itemIndexes(parent string, names []string) []int {
+	prefix := parent + "["
+	seen := make(map[int]bool)
+	var indexes []int
+	for _, name := range names {
+		rest, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		end := strings.IndexByte(rest, ']')
+		if end <= 0 {
+			continue
+		}
+		index, err := strconv.Atoi(rest[:end])
+		if err != nil || index < 0 || seen[index] {
+			continue
+		}
+		seen[index] = true
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
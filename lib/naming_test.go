@@ -0,0 +1,72 @@
+package lib
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestNamingScheme(t *testing.T) {
+	tests := []struct {
+		naming NamingScheme
+		want   string
+	}{
+		{naming: NamingDotted, want: "lines[0].address.street"},
+		{naming: NamingBracket, want: "lines[0][address][street]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.naming), func(t *testing.T) {
+			got := tt.naming.Child(tt.naming.Child(tt.naming.Item("lines", 0), "address"), "street")
+			if got != tt.want {
+				t.Errorf("qualified name = %q, want %q", got, tt.want)
+			}
+			if got := tt.naming.Child("", "street"); got != "street" {
+				t.Errorf("NamingScheme.Child() at the top level = %q, want street", got)
+			}
+		})
+	}
+}
+
+func TestItemIndexes(t *testing.T) {
+	names := []string{"tags[3]", "tags[0]", "tags", "tags[x]", "tags[]", "lines[1].sku", "lines[1].qty", "tagsx[2]", "lines[10][sku]"}
+
+	if got, want := itemIndexes("tags", names), []int{0, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("itemIndexes(tags) = %v, want %v", got, want)
+	}
+	if got, want := itemIndexes("lines", names), []int{1, 10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("itemIndexes(lines) = %v, want %v", got, want)
+	}
+}
+
+func TestForm_DecodeValues_BracketNaming(t *testing.T) {
+	form := &Form{
+		Naming: NamingBracket,
+		Fields: []Field{
+			{Name: "billing", Type: FieldTypeObject, Fields: []Field{{Name: "street", Type: FieldTypeText}}},
+			{Name: "shipping", Type: FieldTypeObject, Fields: []Field{{Name: "street", Type: FieldTypeText}}},
+			{Name: "lines", Type: FieldTypeArray, Fields: []Field{{Name: "item", Type: FieldTypeObject, Fields: []Field{
+				{Name: "sku", Type: FieldTypeText},
+			}}}},
+		},
+	}
+
+	got, err := form.DecodeValues(url.Values{
+		"billing[street]":  {"Main St"},
+		"shipping[street]": {"Side St"},
+		"lines[0][sku]":    {"a"},
+		"lines[1][sku]":    {"b"},
+	})
+	if err != nil {
+		t.Fatalf("Form.DecodeValues() error = %v", err)
+	}
+
+	want := map[string]any{
+		"billing":  map[string]any{"street": "Main St"},
+		"shipping": map[string]any{"street": "Side St"},
+		"lines":    []any{map[string]any{"sku": "a"}, map[string]any{"sku": "b"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Form.DecodeValues() = %#v, want %#v", got, want)
+	}
+}
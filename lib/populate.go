@@ -0,0 +1,166 @@
+package lib
+
+import "fmt"
+
+// Populate returns a copy of the form with every field's Value set from submitted values,
+// as returned by DecodeValues, and its Error set from errs, keyed by the same field paths
+// ValidateValues uses. Errors keyed by the empty path are set as the form's Error.
+// Fields without a submitted value are cleared so the re-rendered form reflects the
+// submission, except hidden fields which keep their fixed Value.
+func (f *Form) Populate(values map[string]any, errs FieldErrors) *Form {
+	return f.PopulateWithVariants(values, errs, nil)
+}
+
+// PopulateWithVariants populates a copy of the form like Populate, marking the variant
+// selected for each variant field, as returned by DecodeVariants, as Selected and giving
+// it the field's value, rather than the variant the value belongs to
+func (f *Form) PopulateWithVariants(values map[string]any, errs FieldErrors, selected SelectedVariants) *Form {
+	if f == nil {
+		return nil
+	}
+
+	populated := *f
+	populated.Error = errs[""]
+	populated.Fields = populateFields(f.Fields, values, "", selected, errs)
+	return &populated
+}
+
+// populateFields populates copies of a list of sibling fields
+func populateFields(fields []Field, values map[string]any, path string, selected SelectedVariants, errs FieldErrors) []Field {
+	if fields == nil {
+		return nil
+	}
+
+	populated := make([]Field, len(fields))
+	for i, field := range fields {
+		populated[i] = populateField(field, values, path, selected, errs)
+	}
+	return populated
+}
+
+// populateField populates a copy of a single field and any conditional fields it carries
+func populateField(field Field, values map[string]any, path string, selected SelectedVariants, errs FieldErrors) Field {
+	fieldPath := joinPath(path, field.Name)
+	value, present := values[field.Name]
+	if field.Name == "" {
+		present = false
+	}
+
+	field = populateValue(field, value, present, fieldPath, selected, errs)
+
+	if field.Name != "" {
+		field.Error = errs[fieldPath]
+	}
+
+	if field.Conditional != nil {
+		conditional := populateConditional(*field.Conditional, values, path, selected, errs)
+		field.Conditional = &conditional
+	}
+	if len(field.Conditionals) > 0 {
+		conditionals := make([]ConditionalField, len(field.Conditionals))
+		for i, conditional := range field.Conditionals {
+			conditionals[i] = populateConditional(conditional, values, path, selected, errs)
+		}
+		field.Conditionals = conditionals
+	}
+
+	return field
+}
+
+// populateConditional populates copies of the fields of a conditional's branches
+func populateConditional(conditional ConditionalField, values map[string]any, path string, selected SelectedVariants, errs FieldErrors) ConditionalField {
+	conditional.Then = populateFields(conditional.Then, values, path, selected, errs)
+	conditional.Else = populateFields(conditional.Else, values, path, selected, errs)
+	return conditional
+}
+
+// populateValue sets the value of a copy of a field, and of the fields nested in it
+func populateValue(field Field, value any, present bool, path string, selected SelectedVariants, errs FieldErrors) Field {
+	switch field.Type {
+	case FieldTypeObject:
+		nested, _ := value.(map[string]any)
+		field.Fields = populateFields(field.Fields, nested, path, selected, errs)
+	case FieldTypeArray:
+		field.Value = value
+		if !present {
+			field.Value = []any{}
+		}
+		field.Items = populateItems(&field, field.Value, path, selected, errs)
+	case FieldTypeVariant:
+		field.Value = value
+		field.Variants = populateVariants(&field, value, path, selected, errs)
+	case FieldTypeHidden:
+		if present {
+			field.Value = value
+		}
+	default:
+		field.Value = value
+		if !present {
+			field.Value = ""
+		}
+	}
+	return field
+}
+
+// populateItems populates one copy of an array field's item field per item of value.
+// Nil items, such as those added by EditItems, are populated as empty items.
+func populateItems(field *Field, value any, path string, selected SelectedVariants, errs FieldErrors) []Field {
+	if len(field.Fields) != 1 {
+		return nil
+	}
+
+	items, _ := toSlice(value)
+	populated := make([]Field, len(items))
+	for i, item := range items {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		populated[i] = populateValue(field.Fields[0], item, item != nil, itemPath, selected, errs)
+		populated[i].Error = errs[itemPath]
+	}
+	return populated
+}
+
+// ItemFields returns the fields the items of an array field are shown with: its Items if
+// populated, otherwise one copy of its item field per item of its Value, or of its Default
+// if no Value is set
+func (field *Field) ItemFields() []Field {
+	if field.Items != nil {
+		return field.Items
+	}
+
+	value := field.Value
+	if value == nil {
+		value = field.Default
+	}
+	return populateItems(field, value, "", nil, FieldErrors{})
+}
+
+// populateVariants populates copies of a variant field's variants. Only the variant selected
+// for the value, or else the one it belongs to, receives it and is marked Selected; the
+// fields of every other variant are cleared.
+func populateVariants(field *Field, value any, path string, selected SelectedVariants, errs FieldErrors) []Variant {
+	if field.Variants == nil {
+		return nil
+	}
+
+	index := field.selectedVariant(value, path, selected)
+	populated := make([]Variant, len(field.Variants))
+	for i, variant := range field.Variants {
+		var nested map[string]any
+		variant.Selected = i == index
+		if variant.Selected {
+			nested, _ = value.(map[string]any)
+			if variant.Scalar {
+				nested = map[string]any{variant.Fields[0].Name: value}
+			}
+		}
+
+		if variant.Scalar {
+			// Errors of a scalar variant are reported on the variant field itself
+			variant.Fields = populateFields(variant.Fields, nested, path, selected, FieldErrors{})
+		} else {
+			variant.Fields = populateFields(variant.Fields, nested, path, selected, errs)
+		}
+		populated[i] = variant
+	}
+	return populated
+}
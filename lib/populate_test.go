@@ -0,0 +1,181 @@
+package lib
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestForm_Populate(t *testing.T) {
+	form := &Form{
+		Title: "Profile",
+		Fields: []Field{
+			{Name: "username", Type: FieldTypeText, Default: "guest"},
+			{Name: "bio", Type: FieldTypeTextarea, Default: "Hello"},
+			{Name: "version", Type: FieldTypeHidden, Value: 2},
+			{Name: "address", Type: FieldTypeObject, Fields: []Field{
+				{Name: "street", Type: FieldTypeText},
+				{Name: "zip", Type: FieldTypeText},
+			}},
+			{Name: "tags", Type: FieldTypeArray, Fields: []Field{{Name: "item", Type: FieldTypeText}}},
+			{Name: "country", Type: FieldTypeText, Conditional: &ConditionalField{
+				Condition: "country",
+				Then:      []Field{{Name: "state", Type: FieldTypeText}},
+			}},
+		},
+	}
+
+	values := map[string]any{
+		"username": "alice",
+		"address":  map[string]any{"street": "Main St"},
+		"tags":     []any{"a", "b"},
+		"state":    "CA",
+	}
+	errs := FieldErrors{
+		"":            "Submission failed",
+		"username":    "Already taken",
+		"address.zip": "This field is required",
+	}
+
+	got := form.Populate(values, errs)
+
+	if got.Error != "Submission failed" {
+		t.Errorf("Form.Populate() form Error = %q, want %q", got.Error, "Submission failed")
+	}
+	if got.Fields[0].Value != "alice" || got.Fields[0].Error != "Already taken" {
+		t.Errorf("Form.Populate() username = %v/%q, want alice/Already taken", got.Fields[0].Value, got.Fields[0].Error)
+	}
+	if got.Fields[1].Value != "" {
+		t.Errorf("Form.Populate() bio Value = %v, want cleared value", got.Fields[1].Value)
+	}
+	if got.Fields[2].Value != 2 {
+		t.Errorf("Form.Populate() hidden Value = %v, want 2", got.Fields[2].Value)
+	}
+
+	address := got.Fields[3]
+	if address.Fields[0].Value != "Main St" || address.Fields[0].Error != "" {
+		t.Errorf("Form.Populate() address.street = %v/%q, want Main St and no error", address.Fields[0].Value, address.Fields[0].Error)
+	}
+	if address.Fields[1].Error != "This field is required" {
+		t.Errorf("Form.Populate() address.zip Error = %q, want required error", address.Fields[1].Error)
+	}
+
+	if tags, ok := got.Fields[4].Value.([]any); !ok || len(tags) != 2 {
+		t.Errorf("Form.Populate() tags Value = %v, want [a b]", got.Fields[4].Value)
+	}
+	if got.Fields[5].Conditional.Then[0].Value != "CA" {
+		t.Errorf("Form.Populate() conditional state Value = %v, want CA", got.Fields[5].Conditional.Then[0].Value)
+	}
+
+	// The original form must be left untouched
+	if form.Fields[0].Value != nil || form.Fields[0].Error != "" || form.Error != "" {
+		t.Errorf("Form.Populate() modified the original form")
+	}
+	if form.Fields[3].Fields[0].Value != nil || form.Fields[5].Conditional.Then[0].Value != nil {
+		t.Errorf("Form.Populate() modified nested fields of the original form")
+	}
+}
+
+func TestForm_Populate_NilForm(t *testing.T) {
+	var form *Form
+	if got := form.Populate(nil, nil); got != nil {
+		t.Errorf("Form.Populate() on nil form = %v, want nil", got)
+	}
+}
+
+func TestForm_Populate_Variants(t *testing.T) {
+	form := &Form{Fields: []Field{paymentField()}}
+	values := map[string]any{"payment": map[string]any{"method": "card", "number": "1234"}}
+	errs := FieldErrors{"payment.number": "Invalid format"}
+
+	got := form.Populate(values, errs).Fields[0]
+
+	card := got.Variants[0].Fields[0]
+	if card.Value != "1234" || card.Error != "Invalid format" {
+		t.Errorf("Form.Populate() card number = %v/%q, want 1234/Invalid format", card.Value, card.Error)
+	}
+	if iban := got.Variants[1].Fields[0]; iban.Value != "" {
+		t.Errorf("Form.Populate() unselected variant iban Value = %v, want cleared value", iban.Value)
+	}
+	if form.Fields[0].Variants[0].Fields[0].Value != nil {
+		t.Errorf("Form.Populate() modified variant fields of the original form")
+	}
+}
+
+func TestForm_Populate_SubmittedVariant(t *testing.T) {
+	form := &Form{Fields: []Field{{Name: "limit", Type: FieldTypeVariant, Variants: []Variant{
+		{Label: "Name", Value: 0, Scalar: true, Fields: []Field{{Name: "value", Type: FieldTypeText}}},
+		{Label: "Count", Value: 1, Scalar: true, Fields: []Field{{Name: "value", Type: FieldTypeNumber, DataType: DataTypeInteger}}},
+	}}}}
+
+	submitted := url.Values{"limit": {"1"}, "limit.value": {"abc"}}
+	values, err := form.DecodeValues(submitted)
+	errs, _ := err.(FieldErrors)
+	if errs["limit"] != "Must be a whole number" {
+		t.Fatalf("Form.DecodeValues() error = %v, want limit to be no whole number", err)
+	}
+	if values["limit"] != "abc" {
+		t.Errorf("Form.DecodeValues() limit = %#v, want the plain submitted string", values["limit"])
+	}
+
+	selected := form.DecodeVariants(submitted)
+	got := form.PopulateWithVariants(values, errs.Merge(form.ValidateValuesWithVariants(values, selected)), selected).Fields[0]
+	if got.Variants[0].Selected || !got.Variants[1].Selected {
+		t.Errorf("Form.PopulateWithVariants() selected = %v/%v, want the submitted Count variant",
+			got.Variants[0].Selected, got.Variants[1].Selected)
+	}
+	if count := got.Variants[1].Fields[0]; count.Value != "abc" {
+		t.Errorf("Form.PopulateWithVariants() count Value = %v, want abc", count.Value)
+	}
+	if got.Error != "Must be a whole number" {
+		t.Errorf("Form.PopulateWithVariants() Error = %q, want the decode error", got.Error)
+	}
+}
+
+func TestForm_Populate_ArrayItems(t *testing.T) {
+	form := &Form{Fields: []Field{
+		{Name: "lines", Type: FieldTypeArray, Fields: []Field{{Name: "item", Type: FieldTypeObject, Fields: []Field{
+			{Name: "sku", Type: FieldTypeText},
+			{Name: "qty", Type: FieldTypeNumber},
+		}}}},
+	}}
+	values := map[string]any{"lines": []any{
+		map[string]any{"sku": "a", "qty": int64(1)},
+		map[string]any{"sku": "b"},
+		nil,
+	}}
+	errs := FieldErrors{"lines[1].qty": "This field is required"}
+
+	items := form.Populate(values, errs).Fields[0].Items
+
+	if len(items) != 3 {
+		t.Fatalf("Form.Populate() got %d items, want 3", len(items))
+	}
+	if sku := items[0].Fields[0]; sku.Value != "a" {
+		t.Errorf("Form.Populate() lines[0].sku Value = %v, want a", sku.Value)
+	}
+	if qty := items[1].Fields[1]; qty.Value != "" || qty.Error != "This field is required" {
+		t.Errorf("Form.Populate() lines[1].qty = %v/%q, want cleared value with required error", qty.Value, qty.Error)
+	}
+	if sku := items[2].Fields[0]; sku.Value != "" {
+		t.Errorf("Form.Populate() added item sku Value = %v, want empty", sku.Value)
+	}
+}
+
+func TestField_ItemFields(t *testing.T) {
+	field := &Field{
+		Name:    "tags",
+		Type:    FieldTypeArray,
+		Default: []string{"go", "templ"},
+		Fields:  []Field{{Name: "item", Type: FieldTypeText}},
+	}
+
+	items := field.ItemFields()
+	if len(items) != 2 || items[0].Value != "go" || items[1].Value != "templ" {
+		t.Errorf("Field.ItemFields() = %+v, want items go and templ from the default", items)
+	}
+
+	field.Value = []any{"html"}
+	if items := field.ItemFields(); len(items) != 1 || items[0].Value != "html" {
+		t.Errorf("Field.ItemFields() = %+v, want a single html item from the value", items)
+	}
+}
@@ -0,0 +1,220 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SchemaSource converts schema documents of a single format into forms. Formats holding
+// several forms, such as the operations of an OpenAPI document, convert the one selected by
+// name; formats holding a single form expect name to be empty.
+// The Form is NOT validated and should be validated before use by the caller.
+type SchemaSource interface {
+	Convert(schema []byte, name string) (*Form, error)
+}
+
+// SchemaSourceFunc adapts a function to the SchemaSource interface
+type SchemaSourceFunc func(schema []byte, name string) (*Form, error)
+
+// Convert calls f(schema, name)
+func (f SchemaSourceFunc) Convert(schema []byte, name string) (*Form, error) {
+	return f(schema, name)
+}
+
+// Target renders forms in a single output format
+type Target interface {
+	Render(ctx context.Context, form *Form, w io.Writer) error
+}
+
+// TargetFunc adapts a function to the Target interface
+type TargetFunc func(ctx context.Context, form *Form, w io.Writer) error
+
+// Render calls f(ctx, form, w)
+func (f TargetFunc) Render(ctx context.Context, form *Form, w io.Writer) error {
+	return f(ctx, form, w)
+}
+
+// Registry holds schema sources and render targets by name and by the media types of the
+// documents they read or write. It is safe for concurrent use.
+type Registry struct {
+	sources entries[SchemaSource]
+	targets entries[Target]
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// DefaultRegistry is the registry the schema and target packages register themselves with
+// when imported. The JSON target, rendering the Form structure itself, is always registered.
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	RegisterTarget("json", TargetFunc(renderJSON), "application/json")
+}
+
+// RegisterSource registers a schema source with the DefaultRegistry, like Registry.RegisterSource
+func RegisterSource(name string, source SchemaSource, mediaTypes ...string) {
+	DefaultRegistry.RegisterSource(name, source, mediaTypes...)
+}
+
+// RegisterTarget registers a render target with the DefaultRegistry, like Registry.RegisterTarget
+func RegisterTarget(name string, target Target, mediaTypes ...string) {
+	DefaultRegistry.RegisterTarget(name, target, mediaTypes...)
+}
+
+// RegisterSource registers a schema source under name and the media types of the schemas
+// it reads. It panics if source is nil or the name or a media type is already registered.
+func (r *Registry) RegisterSource(name string, source SchemaSource, mediaTypes ...string) {
+	if source == nil {
+		panic("lib: schema source " + name + " cannot be nil")
+	}
+	if err := r.sources.register(name, source, mediaTypes); err != nil {
+		panic("lib: schema source " + err.Error())
+	}
+}
+
+// RegisterTarget registers a render target under name and the media types of the output
+// it writes. It panics if target is nil or the name or a media type is already registered.
+func (r *Registry) RegisterTarget(name string, target Target, mediaTypes ...string) {
+	if target == nil {
+		panic("lib: target " + name + " cannot be nil")
+	}
+	if err := r.targets.register(name, target, mediaTypes); err != nil {
+		panic("lib: target " + err.Error())
+	}
+}
+
+// Source returns the schema source registered under a name or media type
+func (r *Registry) Source(key string) (SchemaSource, error) {
+	source, ok := r.sources.lookup(key)
+	if !ok {
+		return nil, fmt.Errorf("unknown schema source %q (must be one of: %s)", key, strings.Join(r.SourceNames(), ", "))
+	}
+	return source, nil
+}
+
+// Target returns the render target registered under a name or media type
+func (r *Registry) Target(key string) (Target, error) {
+	target, ok := r.targets.lookup(key)
+	if !ok {
+		return nil, fmt.Errorf("unknown target %q (must be one of: %s)", key, strings.Join(r.TargetNames(), ", "))
+	}
+	return target, nil
+}
+
+// SourceNames returns the names of the registered schema sources in alphabetical order
+func (r *Registry) SourceNames() []string {
+	return r.sources.names()
+}
+
+// TargetNames returns the names of the registered render targets in alphabetical order
+func (r *Registry) TargetNames() []string {
+	return r.targets.names()
+}
+
+// Convert converts a schema with the source registered under a name or media type
+func (r *Registry) Convert(source string, schema []byte, name string) (*Form, error) {
+	s, err := r.Source(source)
+	if err != nil {
+		return nil, err
+	}
+	return s.Convert(schema, name)
+}
+
+// Render renders a form with the target registered under a name or media type
+func (r *Registry) Render(ctx context.Context, target string, form *Form, w io.Writer) error {
+	t, err := r.Target(target)
+	if err != nil {
+		return err
+	}
+	return t.Render(ctx, form, w)
+}
+
+// entries holds the sources or targets of a registry by name and media type
+type entries[T any] struct {
+	mu          sync.RWMutex
+	byName      map[string]T
+	byMediaType map[string]T
+}
+
+// register adds an entry under name and media types, failing if any of them is taken
+func (e *entries[T]) register(name string, value T, mediaTypes []string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if _, ok := e.byName[name]; ok {
+		return fmt.Errorf("%s is already registered", name)
+	}
+	normalized := make([]string, len(mediaTypes))
+	for i, mediaType := range mediaTypes {
+		normalized[i] = normalizeMediaType(mediaType)
+		if _, ok := e.byMediaType[normalized[i]]; ok {
+			return fmt.Errorf("%s: media type %s is already registered", name, mediaType)
+		}
+	}
+
+	if e.byName == nil {
+		e.byName = make(map[string]T)
+		e.byMediaType = make(map[string]T)
+	}
+	e.byName[name] = value
+	for _, mediaType := range normalized {
+		e.byMediaType[mediaType] = value
+	}
+	return nil
+}
+
+// lookup returns the entry registered under a name, or else under a media type, ignoring
+// media type parameters and case
+func (e *entries[T]) lookup(key string) (T, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if value, ok := e.byName[key]; ok {
+		return value, true
+	}
+	value, ok := e.byMediaType[normalizeMediaType(key)]
+	return value, ok
+}
+
+// names returns the registered names in alphabetical order
+func (e *entries[T]) names() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	names := make([]string, 0, len(e.byName))
+	for name := range e.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// normalizeMediaType lower-cases a media type and strips its parameters
+func normalizeMediaType(mediaType string) string {
+	if parsed, _, err := mime.ParseMediaType(mediaType); err == nil {
+		return parsed
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// renderJSON renders a form as the indented JSON of the Form structure
+func renderJSON(_ context.Context, form *Form, w io.Writer) error {
+	data, err := json.MarshalIndent(form, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
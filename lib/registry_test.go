@@ -0,0 +1,119 @@
+package lib
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	registry.RegisterSource("csv", SchemaSourceFunc(func(schema []byte, name string) (*Form, error) {
+		fields := []Field{}
+		for _, column := range strings.Split(string(schema), ",") {
+			fields = append(fields, Field{Name: column, Type: FieldTypeText})
+		}
+		return &Form{Title: name, Fields: fields}, nil
+	}), "text/csv")
+	registry.RegisterTarget("names", TargetFunc(func(_ context.Context, form *Form, w io.Writer) error {
+		for _, field := range form.Fields {
+			fmt.Fprintln(w, field.Name)
+		}
+		return nil
+	}), "text/plain")
+	json, err := DefaultRegistry.Target("json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry.RegisterTarget("json", json, "application/json")
+
+	tests := []struct {
+		name   string
+		source string
+		target string
+		want   string
+		errMsg string
+	}{
+		{name: "by name", source: "csv", target: "names", want: "email\nname\n"},
+		{name: "by media type", source: "TEXT/CSV; charset=utf-8", target: "text/plain", want: "email\nname\n"},
+		{name: "json target", source: "csv", target: "application/json", want: `"title": "Signup"`},
+		{name: "unknown source", source: "toml", target: "names", errMsg: `unknown schema source "toml" (must be one of: csv)`},
+		{name: "unknown target", source: "csv", target: "xml", errMsg: `unknown target "xml" (must be one of: json, names)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form, err := registry.Convert(tt.source, []byte("email,name"), "Signup")
+			var buf bytes.Buffer
+			if err == nil {
+				err = registry.Render(context.Background(), tt.target, form, &buf)
+			}
+			if tt.errMsg != "" {
+				if err == nil || err.Error() != tt.errMsg {
+					t.Fatalf("error = %v, want %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("output = %q, want it to contain %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_Names(t *testing.T) {
+	registry := NewRegistry()
+	source := SchemaSourceFunc(func([]byte, string) (*Form, error) { return nil, nil })
+	registry.RegisterSource("yaml", source)
+	registry.RegisterSource("jsonschema", source, "application/schema+json")
+
+	if got, want := registry.SourceNames(), []string{"jsonschema", "yaml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SourceNames() = %v, want %v", got, want)
+	}
+	if got := registry.TargetNames(); len(got) != 0 {
+		t.Errorf("TargetNames() = %v, want none", got)
+	}
+	if got := DefaultRegistry.TargetNames(); !reflect.DeepEqual(got, []string{"json"}) {
+		t.Errorf("DefaultRegistry.TargetNames() = %v, want [json]", got)
+	}
+}
+
+func TestRegistry_RegisterPanics(t *testing.T) {
+	source := SchemaSourceFunc(func([]byte, string) (*Form, error) { return nil, nil })
+
+	tests := []struct {
+		name     string
+		register func(*Registry)
+		want     string
+	}{
+		{name: "nil source", register: func(r *Registry) { r.RegisterSource("nil", nil) }, want: "lib: schema source nil cannot be nil"},
+		{name: "nil target", register: func(r *Registry) { r.RegisterTarget("nil", nil) }, want: "lib: target nil cannot be nil"},
+		{name: "empty name", register: func(r *Registry) { r.RegisterSource("", source) }, want: "lib: schema source name cannot be empty"},
+		{name: "duplicate name", register: func(r *Registry) { r.RegisterSource("jsonschema", source) }, want: "lib: schema source jsonschema is already registered"},
+		{
+			name:     "duplicate media type",
+			register: func(r *Registry) { r.RegisterSource("other", source, "Application/Schema+JSON") },
+			want:     "lib: schema source other: media type Application/Schema+JSON is already registered",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.RegisterSource("jsonschema", source, "application/schema+json")
+			defer func() {
+				if got := recover(); got != tt.want {
+					t.Errorf("panic = %v, want %q", got, tt.want)
+				}
+			}()
+			tt.register(registry)
+		})
+	}
+}
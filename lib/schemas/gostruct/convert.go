@@ -0,0 +1,378 @@
+// Package gostruct generates forms from Go struct types, so request structs need no
+// parallel JSON Schema. Fields are named after their json tags and typed after their Go
+// types, matching the form jsonschema.ConvertSchemaToForm generates from the JSON Schema
+// of the same JSON document:
+//
+//	type Signup struct {
+//		Email    string    `json:"email" validate:"required,email" label:"Email address"`
+//		Name     string    `json:"name" validate:"min=2,max=50"`
+//		Bio      string    `json:"bio,omitempty" form:"type=textarea,placeholder=Tell us about you"`
+//		Birthday time.Time `json:"birthday" form:"type=date"`
+//		Plan     Plan      `json:"plan"` // Plan implements Enum
+//	}
+package gostruct
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/Olian04/form-from-schema/lib"
+	"github.com/Olian04/form-from-schema/lib/schemas/jsonschema"
+)
+
+// Enum is implemented by types limited to a fixed set of values, such as a string type with
+// constants. The values become the options of every field of the type.
+type Enum interface {
+	EnumValues() []any
+}
+
+var (
+	timeType = reflect.TypeFor[time.Time]()
+	enumType = reflect.TypeFor[Enum]()
+)
+
+// Options configures struct to form conversion. The zero value converts like
+// ConvertStructToForm.
+type Options struct {
+	// Label returns the label of a field without a label tag from its json name, and the
+	// labels of enum values as lib.ValueLabels generates them. lib.HumanizeLabel if nil; use
+	// a lib.Labeler for a dictionary of your own.
+	Label func(name string) string
+}
+
+// ConvertStructToForm converts a struct, or a pointer to one, to a Form structure. Fields
+// of v that are not zero become the defaults of their form fields.
+func ConvertStructToForm(v any) (*lib.Form, error) {
+	return Options{}.Convert(v)
+}
+
+// Convert converts a struct, or a pointer to one, to a Form structure according to the
+// options, like ConvertStructToForm
+func (o Options) Convert(v any) (*lib.Form, error) {
+	if o.Label == nil {
+		o.Label = lib.HumanizeLabel
+	}
+
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Pointer {
+		value = reflect.Zero(value.Type().Elem())
+	}
+	if !value.IsValid() || value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct, got %T", v)
+	}
+
+	c := &converter{label: o.Label, converting: make(map[reflect.Type]bool)}
+	fields, err := c.convertStructFields(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lib.Form{
+		Method: "POST", // Default
+		Fields: fields,
+	}, nil
+}
+
+// ConvertTypeToForm converts a struct type, or a pointer to one, to a Form structure
+func ConvertTypeToForm(t reflect.Type) (*lib.Form, error) {
+	if t == nil {
+		return nil, fmt.Errorf("type cannot be nil")
+	}
+	return ConvertStructToForm(reflect.Zero(t).Interface())
+}
+
+// converter holds the state shared by a single struct to form conversion
+type converter struct {
+	label      func(string) string   // Generates the labels of fields and enum values
+	converting map[reflect.Type]bool // Struct types currently being converted, used to detect cycles
+}
+
+// convertStructFields converts the exported fields of a struct to form fields in declaration
+// order. Embedded structs without a json name have their fields promoted, like encoding/json.
+func (c *converter) convertStructFields(value reflect.Value) ([]lib.Field, error) {
+	t := value.Type()
+	if c.converting[t] {
+		return nil, fmt.Errorf("recursive type %s", t)
+	}
+	c.converting[t] = true
+	defer delete(c.converting, t)
+
+	var fields []lib.Field
+	for i := range t.NumField() {
+		structField := t.Field(i)
+		name, named, skip := jsonName(structField.Tag.Get("json"), structField.Name)
+		form := parseFormTag(structField.Tag.Get("form"))
+		if skip || form.skip || (!structField.IsExported() && !structField.Anonymous) {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if structField.Anonymous && !named {
+			embedded, ok := embeddedStruct(fieldValue)
+			if ok {
+				promoted, err := c.convertStructFields(embedded)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, promoted...)
+				continue
+			}
+			if !structField.IsExported() {
+				continue
+			}
+		}
+
+		field, err := c.convertField(name, fieldValue, structField.Tag, form)
+		if err != nil {
+			return nil, fmt.Errorf("error converting field %s: %w", name, err)
+		}
+		fields = append(fields, *field)
+	}
+	return fields, nil
+}
+
+// embeddedStruct returns the struct an embedded field promotes the fields of
+func embeddedStruct(value reflect.Value) (reflect.Value, bool) {
+	if value.Kind() == reflect.Pointer {
+		if value.Type().Elem().Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		if value.IsNil() {
+			return reflect.Zero(value.Type().Elem()), true
+		}
+		value = value.Elem()
+	}
+	return value, value.Kind() == reflect.Struct && value.Type() != timeType
+}
+
+// convertField converts a struct field, with the given tags, to a form field
+func (c *converter) convertField(name string, value reflect.Value, tag reflect.StructTag, form formTag) (*lib.Field, error) {
+	field, err := c.convertValue(name, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if label := tag.Get("label"); label != "" {
+		field.Label = label
+	}
+	field.Placeholder = form.placeholder
+	field.ReadOnly = form.readOnly
+	field.Deprecated = form.deprecated
+
+	rules := parseValidateTag(tag.Get("validate"))
+	c.applyRules(field, rules)
+
+	if form.fieldType != "" {
+		field.Type = form.fieldType
+	}
+	if t, ok := field.Default.(time.Time); ok {
+		field.Default = formatTime(t, field.Type)
+	}
+	return field, nil
+}
+
+// formatTime formats a time the way a field of the given type submits it: as a date or
+// time of day for date and time fields, and as an RFC 3339 date-time otherwise
+func formatTime(t time.Time, fieldType lib.FieldType) string {
+	switch fieldType {
+	case lib.FieldTypeDate:
+		return t.Format(time.DateOnly)
+	case lib.FieldTypeTime:
+		return t.Format(time.TimeOnly)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// convertValue converts a value of a Go type to a form field, with the value as default
+// unless it is zero
+func (c *converter) convertValue(name string, value reflect.Value) (*lib.Field, error) {
+	t := value.Type()
+	for t.Kind() == reflect.Pointer && !t.Implements(enumType) {
+		if value.IsNil() {
+			value = reflect.Zero(t.Elem())
+		} else {
+			value = value.Elem()
+		}
+		t = value.Type()
+	}
+
+	field := &lib.Field{Name: name, Label: c.label(name)}
+
+	if values := enumValues(t); len(values) > 0 {
+		field.Options = c.convertEnumToOptions(values)
+		field.DataType = dataTypeOf(t)
+		if len(field.Options) <= jsonschema.DefaultRadioThreshold {
+			field.Type = lib.FieldTypeRadio
+		} else {
+			field.Type = lib.FieldTypeSelect
+		}
+		setDefault(field, value)
+		return field, nil
+	}
+
+	switch {
+	case t == timeType:
+		field.Type = lib.FieldTypeDateTime
+		field.DataType = lib.DataTypeString
+		setDefault(field, value)
+		return field, nil
+
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() == reflect.Uint8:
+		// Bytes are encoded as base64 strings, the way file uploads are decoded
+		field.Type = lib.FieldTypeFile
+		field.DataType = lib.DataTypeString
+		return field, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		field.Type = lib.FieldTypeText
+		field.DataType = lib.DataTypeString
+	case reflect.Bool:
+		field.Type = lib.FieldTypeCheckbox
+		field.DataType = lib.DataTypeBoolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.Type = lib.FieldTypeNumber
+		field.DataType = lib.DataTypeInteger
+	case reflect.Float32, reflect.Float64:
+		field.Type = lib.FieldTypeNumber
+		field.DataType = lib.DataTypeNumber
+
+	case reflect.Struct:
+		field.Type = lib.FieldTypeObject
+		field.DataType = lib.DataTypeObject
+		nested, err := c.convertStructFields(value)
+		if err != nil {
+			return nil, err
+		}
+		field.Fields = nested
+		return field, nil
+
+	case reflect.Slice, reflect.Array:
+		field.Type = lib.FieldTypeArray
+		field.DataType = lib.DataTypeArray
+		item, err := c.convertValue("item", reflect.Zero(t.Elem()))
+		if err != nil {
+			return nil, err
+		}
+		field.Fields = []lib.Field{*item}
+		return field, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+
+	setDefault(field, value)
+	return field, nil
+}
+
+// enumValues returns the values of a type implementing Enum, on its value or pointer receiver
+func enumValues(t reflect.Type) []any {
+	if t.Implements(enumType) {
+		return reflect.Zero(t).Interface().(Enum).EnumValues()
+	}
+	if reflect.PointerTo(t).Implements(enumType) {
+		return reflect.New(t).Interface().(Enum).EnumValues()
+	}
+	return nil
+}
+
+// dataTypeOf returns the JSON type of the values of a scalar Go type
+func dataTypeOf(t reflect.Type) lib.DataType {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return lib.DataTypeString
+	case reflect.Bool:
+		return lib.DataTypeBoolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return lib.DataTypeInteger
+	case reflect.Float32, reflect.Float64:
+		return lib.DataTypeNumber
+	}
+	return ""
+}
+
+// convertEnumToOptions converts enum values to Option structs, labeled by lib.ValueLabels
+func (c *converter) convertEnumToOptions(enum []any) []lib.Option {
+	labels := lib.ValueLabels(enum, c.label)
+	options := make([]lib.Option, 0, len(enum))
+	for i, value := range enum {
+		options = append(options, lib.Option{
+			Label: labels[i],
+			Value: value,
+		})
+	}
+	return options
+}
+
+// setDefault sets the default of a scalar field to value, unless it is zero
+func setDefault(field *lib.Field, value reflect.Value) {
+	if value.IsValid() && !value.IsZero() {
+		field.Default = value.Interface()
+	}
+}
+
+// applyRules applies the rules of a validate tag to a field. Bounds limit the length of
+// text, the value of numbers and the number of items of arrays.
+func (c *converter) applyRules(field *lib.Field, rules validateTag) {
+	if rules.email && field.Type == lib.FieldTypeText {
+		field.Type = lib.FieldTypeEmail
+	}
+	if rules.url && field.Type == lib.FieldTypeText {
+		field.Type = lib.FieldTypeURL
+	}
+	if len(rules.oneOf) > 0 && len(field.Options) == 0 {
+		values := make([]any, len(rules.oneOf))
+		for i, value := range rules.oneOf {
+			values[i] = value
+		}
+		field.Options = c.convertEnumToOptions(values)
+		if len(field.Options) <= jsonschema.DefaultRadioThreshold {
+			field.Type = lib.FieldTypeRadio
+		} else {
+			field.Type = lib.FieldTypeSelect
+		}
+	}
+
+	validation := &lib.Validation{Required: rules.required}
+	switch {
+	case field.Type == lib.FieldTypeArray:
+		validation.MinItems = toInt(rules.min, rules.exclusiveMin, 1)
+		validation.MaxItems = toInt(rules.max, rules.exclusiveMax, -1)
+	case field.DataType == lib.DataTypeString && len(field.Options) == 0:
+		validation.MinLength = toInt(rules.min, rules.exclusiveMin, 1)
+		validation.MaxLength = toInt(rules.max, rules.exclusiveMax, -1)
+		if validation.MaxLength != nil && *validation.MaxLength > jsonschema.DefaultTextareaThreshold && field.Type == lib.FieldTypeText {
+			field.Type = lib.FieldTypeTextarea
+		}
+	case field.Type == lib.FieldTypeNumber:
+		validation.Min, validation.ExclusiveMin = rules.min, rules.exclusiveMin && rules.min != nil
+		validation.Max, validation.ExclusiveMax = rules.max, rules.exclusiveMax && rules.max != nil
+	}
+
+	if *validation != (lib.Validation{}) {
+		field.Validation = validation
+	}
+}
+
+// toInt converts a bound to a length or count, moving an exclusive bound by offset so the
+// count is within it
+func toInt(bound *float64, exclusive bool, offset int) *int {
+	if bound == nil {
+		return nil
+	}
+	n := int(*bound)
+	if exclusive {
+		n += offset
+	}
+	return &n
+}
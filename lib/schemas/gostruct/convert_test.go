@@ -0,0 +1,270 @@
+package gostruct
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Olian04/form-from-schema/lib"
+	"github.com/Olian04/form-from-schema/lib/schemas/jsonschema"
+)
+
+type plan string
+
+func (plan) EnumValues() []any {
+	return []any{"free", "pro"}
+}
+
+type priority int
+
+func (*priority) EnumValues() []any {
+	return []any{1, 2, 3, 4}
+}
+
+type address struct {
+	Street string `json:"street" validate:"required"`
+	Zip    string `json:"zip,omitempty"`
+}
+
+type audit struct {
+	CreatedBy string `json:"created_by" form:"readonly"`
+}
+
+type signup struct {
+	audit
+	Email    string    `json:"email" validate:"required,email" label:"Email address"`
+	Name     string    `json:"name" validate:"min=2,max=50"`
+	Bio      string    `json:"bio,omitempty" form:"type=textarea,placeholder=Tell us about you"`
+	Age      *int      `json:"age,omitempty" validate:"gte=18,lte=120"`
+	Score    float64   `json:"score" validate:"gt=0"`
+	Admin    bool      `json:"admin"`
+	Birthday time.Time `json:"birthday" form:"type=date"`
+	Plan     plan      `json:"plan"`
+	Priority priority  `json:"priority"`
+	Address  *address  `json:"address"`
+	Tags     []string  `json:"tags" validate:"lt=6"`
+	Avatar   []byte    `json:"avatar"`
+	Secret   string    `json:"-"`
+	Internal string    `json:"internal" form:"-"`
+	NoTag    string
+	private  string
+}
+
+func TestConvertStructToForm(t *testing.T) {
+	form, err := ConvertStructToForm(&signup{Name: "Ada", private: "left out", Birthday: time.Date(1815, 12, 10, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("ConvertStructToForm() error = %v", err)
+	}
+	if err := form.Validate(); err != nil {
+		t.Fatalf("Form.Validate() error = %v", err)
+	}
+	if form.Method != "POST" {
+		t.Errorf("Method = %q, want POST", form.Method)
+	}
+
+	names := make([]string, len(form.Fields))
+	for i, field := range form.Fields {
+		names[i] = field.Name
+	}
+	wantNames := "created_by,email,name,bio,age,score,admin,birthday,plan,priority,address,tags,avatar,NoTag"
+	if got := strings.Join(names, ","); got != wantNames {
+		t.Fatalf("fields = %s, want %s", got, wantNames)
+	}
+
+	field := func(name string) lib.Field {
+		for _, field := range form.Fields {
+			if field.Name == name {
+				return field
+			}
+		}
+		t.Fatalf("no field %s", name)
+		return lib.Field{}
+	}
+
+	tests := []struct {
+		name  string
+		check func(lib.Field) bool
+	}{
+		{"created_by", func(f lib.Field) bool { return f.ReadOnly && f.Type == lib.FieldTypeText }},
+		{"email", func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeEmail && f.Label == "Email address" && f.Validation.Required
+		}},
+		{"name", func(f lib.Field) bool {
+			return *f.Validation.MinLength == 2 && *f.Validation.MaxLength == 50 && f.Default == "Ada"
+		}},
+		{"bio", func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeTextarea && f.Placeholder == "Tell us about you" && f.Validation == nil
+		}},
+		{"age", func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeNumber && f.DataType == lib.DataTypeInteger &&
+				*f.Validation.Min == 18 && *f.Validation.Max == 120 && !f.Validation.Required
+		}},
+		{"score", func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeNumber && f.DataType == lib.DataTypeNumber &&
+				*f.Validation.Min == 0 && f.Validation.ExclusiveMin && f.Validation.Max == nil
+		}},
+		{"admin", func(f lib.Field) bool { return f.Type == lib.FieldTypeCheckbox && f.DataType == lib.DataTypeBoolean }},
+		{"birthday", func(f lib.Field) bool { return f.Type == lib.FieldTypeDate && f.Default == "1815-12-10" }},
+		{"plan", func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeRadio && f.DataType == lib.DataTypeString && len(f.Options) == 2 && f.Options[1].Value == "pro"
+		}},
+		{"priority", func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeSelect && f.DataType == lib.DataTypeInteger && len(f.Options) == 4
+		}},
+		{"address", func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeObject && len(f.Fields) == 2 && f.Fields[0].Validation.Required
+		}},
+		{"tags", func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeArray && f.Fields[0].Name == "item" && *f.Validation.MaxItems == 5
+		}},
+		{"avatar", func(f lib.Field) bool { return f.Type == lib.FieldTypeFile && f.DataType == lib.DataTypeString }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if f := field(tt.name); !tt.check(f) {
+				data, _ := json.Marshal(f)
+				t.Errorf("unexpected field %s", data)
+			}
+		})
+	}
+}
+
+func TestConvertStructToForm_MatchesJsonSchema(t *testing.T) {
+	type item struct {
+		SKU string `json:"sku" validate:"required"`
+		Qty int    `json:"qty" validate:"min=1"`
+	}
+	type order struct {
+		Email string    `json:"email" validate:"required,email"`
+		Note  string    `json:"note" validate:"max=500"`
+		When  time.Time `json:"when"`
+		Gift  bool      `json:"gift"`
+		Lines []item    `json:"lines" validate:"min=1"`
+	}
+
+	fromStruct, err := ConvertStructToForm(order{})
+	if err != nil {
+		t.Fatalf("ConvertStructToForm() error = %v", err)
+	}
+
+	schema, err := jsonschema.Parse([]byte(`{
+		"type": "object",
+		"properties": {
+			"email": {"type": "string", "format": "email"},
+			"note": {"type": "string", "maxLength": 500},
+			"when": {"type": "string", "format": "date-time"},
+			"gift": {"type": "boolean"},
+			"lines": {
+				"type": "array",
+				"minItems": 1,
+				"items": {
+					"type": "object",
+					"properties": {
+						"sku": {"type": "string"},
+						"qty": {"type": "integer", "minimum": 1}
+					},
+					"required": ["sku"]
+				}
+			}
+		},
+		"required": ["email"]
+	}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	fromSchema, err := jsonschema.ConvertSchemaToForm(schema)
+	if err != nil {
+		t.Fatalf("ConvertSchemaToForm() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(fromStruct, fromSchema) {
+		got, _ := json.Marshal(fromStruct)
+		want, _ := json.Marshal(fromSchema)
+		t.Errorf("ConvertStructToForm() = %s, want %s", got, want)
+	}
+}
+
+func TestConvertStructToForm_Errors(t *testing.T) {
+	type node struct {
+		Children []node `json:"children"`
+	}
+	type withMap struct {
+		Labels map[string]string `json:"labels"`
+	}
+
+	tests := []struct {
+		name   string
+		value  any
+		errMsg string
+	}{
+		{name: "not a struct", value: "text", errMsg: "expected a struct, got string"},
+		{name: "nil", value: nil, errMsg: "expected a struct, got <nil>"},
+		{name: "recursive type", value: node{}, errMsg: "recursive type gostruct.node"},
+		{name: "unsupported type", value: withMap{}, errMsg: "error converting field labels: unsupported type map[string]string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertStructToForm(tt.value)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("ConvertStructToForm() error = %v, want it to contain %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestConvertTypeToForm(t *testing.T) {
+	form, err := ConvertTypeToForm(reflect.TypeFor[*address]())
+	if err != nil {
+		t.Fatalf("ConvertTypeToForm() error = %v", err)
+	}
+	if len(form.Fields) != 2 || form.Fields[0].Name != "street" {
+		t.Errorf("fields = %+v, want street and zip", form.Fields)
+	}
+}
+
+func TestOptions_Label(t *testing.T) {
+	type order struct {
+		OrderID  string `json:"orderID"`
+		Operator string `json:"operator" validate:"oneof=+ - * /"`
+		Status   string `json:"status" validate:"oneof=in_progress done"`
+	}
+
+	tests := []struct {
+		name  string
+		label func(string) string
+		want  []string
+	}{
+		{
+			name: "humanized",
+			want: []string{"Order ID", "Operator", "+", "-", "*", "/", "Status", "In progress", "Done"},
+		},
+		{
+			name:  "custom dictionary",
+			label: lib.Labeler{Words: map[string]string{"id": "number"}}.Label,
+			want:  []string{"Order number", "Operator", "+", "-", "*", "/", "Status", "In progress", "Done"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form, err := Options{Label: tt.label}.Convert(order{})
+			if err != nil {
+				t.Fatalf("Options.Convert() error = %v", err)
+			}
+			var got []string
+			for _, field := range form.Fields {
+				got = append(got, field.Label)
+				for _, option := range field.Options {
+					got = append(got, option.Label)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("labels = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
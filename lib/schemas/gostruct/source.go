@@ -0,0 +1,67 @@
+package gostruct
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+// structs holds the struct types registered with Register, by name
+var structs = struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+}{types: make(map[string]reflect.Type)}
+
+func init() {
+	lib.RegisterSource("gostruct", lib.SchemaSourceFunc(convert))
+}
+
+// Register registers the struct type of v, a struct or a pointer to one, under name, so the
+// "gostruct" schema source converts it when selected by name
+func Register(name string, v any) error {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a struct, got %T", v)
+	}
+
+	structs.Lock()
+	defer structs.Unlock()
+	if _, ok := structs.types[name]; ok {
+		return fmt.Errorf("struct %q is already registered", name)
+	}
+	structs.types[name] = t
+	return nil
+}
+
+// convert is the schema source of registered struct types, converting the struct
+// registered under name. The schema, if not empty, is a JSON document of the struct whose
+// values become the defaults of their fields.
+func convert(data []byte, name string) (*lib.Form, error) {
+	structs.RLock()
+	t, ok := structs.types[name]
+	names := make([]string, 0, len(structs.types))
+	for registered := range structs.types {
+		names = append(names, registered)
+	}
+	structs.RUnlock()
+	if !ok {
+		sort.Strings(names)
+		return nil, fmt.Errorf("struct %q is not registered (must be one of: %s)", name, strings.Join(names, ", "))
+	}
+
+	value := reflect.New(t)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, value.Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return ConvertStructToForm(value.Interface())
+}
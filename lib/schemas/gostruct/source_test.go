@@ -0,0 +1,41 @@
+package gostruct
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	type Login struct {
+		User string `json:"user"`
+	}
+	if err := Register("login", &Login{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		v      any
+		errMsg string
+	}{
+		{name: "login", v: Login{}, errMsg: `struct "login" is already registered`},
+		{name: "count", v: 3, errMsg: "expected a struct, got int"},
+		{name: "nil", v: nil, errMsg: "expected a struct, got <nil>"},
+	}
+	for _, tt := range tests {
+		if err := Register(tt.name, tt.v); err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("Register(%s) error = %v, want it to contain %q", tt.name, err, tt.errMsg)
+		}
+	}
+
+	form, err := convert([]byte(`{"user": "ada"}`), "login")
+	if err != nil {
+		t.Fatalf("convert() error = %v", err)
+	}
+	if form.Fields[0].Name != "user" || form.Fields[0].Default != "ada" {
+		t.Errorf("convert() fields = %+v, want user defaulting to ada", form.Fields)
+	}
+	if _, err := convert([]byte(`{"user": 1}`), "login"); err == nil {
+		t.Error("convert() error = nil, want error for a schema of the wrong type")
+	}
+}
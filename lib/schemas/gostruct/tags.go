@@ -0,0 +1,112 @@
+package gostruct
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+// jsonName returns the property name of a struct field from its json tag, whether the
+// tag names it explicitly, and whether the field is left out of JSON altogether
+func jsonName(tag, fieldName string) (name string, named bool, skip bool) {
+	if tag == "-" {
+		return "", false, true
+	}
+	name, _, _ = strings.Cut(tag, ",")
+	if name == "" {
+		return fieldName, false, false
+	}
+	return name, true, false
+}
+
+// formTag holds the options of a form tag, e.g. `form:"type=textarea,placeholder=Say hi"`.
+// Values cannot contain commas.
+type formTag struct {
+	skip        bool          // "-": the field is left out of the form
+	fieldType   lib.FieldType // "type=...": the field type, instead of the one derived from the Go type
+	placeholder string        // "placeholder=..."
+	readOnly    bool          // "readonly"
+	deprecated  bool          // "deprecated"
+}
+
+// parseFormTag parses a form tag, ignoring unknown options
+func parseFormTag(tag string) formTag {
+	var parsed formTag
+	if tag == "-" {
+		parsed.skip = true
+		return parsed
+	}
+	for _, option := range splitTag(tag) {
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "type":
+			parsed.fieldType = lib.FieldType(value)
+		case "placeholder":
+			parsed.placeholder = value
+		case "readonly":
+			parsed.readOnly = true
+		case "deprecated":
+			parsed.deprecated = true
+		}
+	}
+	return parsed
+}
+
+// validateTag holds the rules of a validate tag, in the syntax of
+// github.com/go-playground/validator, that can be expressed as form validation
+type validateTag struct {
+	required     bool
+	min, max     *float64 // min/gte/gt and max/lte/lt, or both for len
+	exclusiveMin bool     // The minimum is from gt
+	exclusiveMax bool     // The maximum is from lt
+	email        bool
+	url          bool
+	oneOf        []string
+}
+
+// parseValidateTag parses a validate tag, ignoring rules without a form counterpart
+func parseValidateTag(tag string) validateTag {
+	var parsed validateTag
+	for _, rule := range splitTag(tag) {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "required":
+			parsed.required = true
+		case "min", "gte", "gt":
+			parsed.min, parsed.exclusiveMin = parseBound(value), key == "gt"
+		case "max", "lte", "lt":
+			parsed.max, parsed.exclusiveMax = parseBound(value), key == "lt"
+		case "len":
+			parsed.min, parsed.exclusiveMin = parseBound(value), false
+			parsed.max, parsed.exclusiveMax = parseBound(value), false
+		case "email":
+			parsed.email = true
+		case "url", "uri", "http_url":
+			parsed.url = true
+		case "oneof":
+			parsed.oneOf = strings.Fields(value)
+		}
+	}
+	return parsed
+}
+
+// parseBound parses the number of a min/max rule, or returns nil if it is not a number
+func parseBound(value string) *float64 {
+	bound, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil
+	}
+	return &bound
+}
+
+// splitTag splits a comma separated tag into its non-empty options
+func splitTag(tag string) []string {
+	var options []string
+	for _, option := range strings.Split(tag, ",") {
+		if option = strings.TrimSpace(option); option != "" {
+			options = append(options, option)
+		}
+	}
+	return options
+}
@@ -0,0 +1,73 @@
+package gostruct
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJsonName(t *testing.T) {
+	tests := []struct {
+		tag, field string
+		wantName   string
+		wantNamed  bool
+		wantSkip   bool
+	}{
+		{tag: "email,omitempty", field: "Email", wantName: "email", wantNamed: true},
+		{tag: ",omitempty", field: "Email", wantName: "Email"},
+		{tag: "", field: "Email", wantName: "Email"},
+		{tag: "-", field: "Email", wantSkip: true},
+		{tag: "-,", field: "Dash", wantName: "-", wantNamed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			name, named, skip := jsonName(tt.tag, tt.field)
+			if name != tt.wantName || named != tt.wantNamed || skip != tt.wantSkip {
+				t.Errorf("jsonName(%q) = %q, %v, %v, want %q, %v, %v", tt.tag, name, named, skip, tt.wantName, tt.wantNamed, tt.wantSkip)
+			}
+		})
+	}
+}
+
+func TestParseFormTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want formTag
+	}{
+		{tag: "-", want: formTag{skip: true}},
+		{tag: "type=textarea, placeholder=Say hi", want: formTag{fieldType: "textarea", placeholder: "Say hi"}},
+		{tag: "readonly,deprecated,unknown=1", want: formTag{readOnly: true, deprecated: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := parseFormTag(tt.tag); got != tt.want {
+				t.Errorf("parseFormTag(%q) = %+v, want %+v", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseValidateTag(t *testing.T) {
+	two, ten := 2.0, 10.0
+
+	tests := []struct {
+		tag  string
+		want validateTag
+	}{
+		{tag: "required,email", want: validateTag{required: true, email: true}},
+		{tag: "gte=2,lt=10", want: validateTag{min: &two, max: &ten, exclusiveMax: true}},
+		{tag: "gt=2,lte=10", want: validateTag{min: &two, max: &ten, exclusiveMin: true}},
+		{tag: "len=2", want: validateTag{min: &two, max: &two}},
+		{tag: "omitempty,url,min=x", want: validateTag{url: true}},
+		{tag: "oneof=red green blue", want: validateTag{oneOf: []string{"red", "green", "blue"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := parseValidateTag(tt.tag); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseValidateTag(%q) = %+v, want %+v", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package jsonschema
+
+import (
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+// attachConditional converts the if/then/else of an object schema. Properties that then or
+// else require among fields become required when the condition holds or fails. The other
+// properties of the branches are shown by a conditional attached to the last of the fields
+// the condition tests, so the branches follow every field they depend on. Properties tested
+// by the condition that have no field are never submitted, so the parts of the condition
+// testing them are decided up front; if that decides the whole condition, the branch that
+// applies is applied unconditionally.
+func (c *converter) attachConditional(schema *Schema, fields []lib.Field) ([]lib.Field, error) {
+	built, err := c.buildCondition(schema.If)
+	if err != nil || built == nil {
+		return fields, err
+	}
+
+	condition, holds := decideAbsent(*built, fields)
+	if condition == nil {
+		branch := schema.Else
+		if holds {
+			branch = schema.Then
+		}
+		if branch == nil {
+			return fields, nil
+		}
+		branchFields, err := c.convertBranch(branch, fields, nil)
+		if err != nil {
+			return nil, err
+		}
+		return append(fields, branchFields...), nil
+	}
+
+	last := -1
+	for _, name := range condition.FieldNames() {
+		last = max(last, fieldIndex(fields, name))
+	}
+
+	conditional := &lib.ConditionalField{When: condition}
+	if schema.Then != nil {
+		if conditional.Then, err = c.convertBranch(schema.Then, fields, condition); err != nil {
+			return nil, err
+		}
+	}
+	if schema.Else != nil {
+		failed := lib.Condition{Operator: lib.ConditionNot, Conditions: []lib.Condition{*condition}}
+		if conditional.Else, err = c.convertBranch(schema.Else, fields, &failed); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(conditional.Then) == 0 && len(conditional.Else) == 0 {
+		return fields, nil
+	}
+	attachConditionalField(&fields[last], conditional)
+	return fields, nil
+}
+
+// attachConditionalField attaches a conditional to a field, after any it already carries
+func attachConditionalField(field *lib.Field, conditional *lib.ConditionalField) {
+	if field.Conditional == nil {
+		field.Conditional = conditional
+		return
+	}
+	field.Conditionals = append(field.Conditionals, *conditional)
+}
+
+// decideAbsent removes the parts of a condition that test properties without a field among
+// fields. Such properties are always absent, so equals, in, range and present on them never
+// hold. If that decides the whole condition, nil is returned along with whether it holds.
+func decideAbsent(condition lib.Condition, fields []lib.Field) (*lib.Condition, bool) {
+	switch condition.Operator {
+	case lib.ConditionAnd, lib.ConditionOr:
+		// A decided part holding for or, or failing for and, decides the whole condition
+		deciding := condition.Operator == lib.ConditionOr
+		var parts []lib.Condition
+		for _, part := range condition.Conditions {
+			remaining, holds := decideAbsent(part, fields)
+			if remaining != nil {
+				parts = append(parts, *remaining)
+			} else if holds == deciding {
+				return nil, holds
+			}
+		}
+		if len(parts) == 0 {
+			return nil, !deciding
+		}
+		return combineConditions(condition.Operator, parts), false
+
+	case lib.ConditionNot:
+		if len(condition.Conditions) != 1 {
+			return &condition, false
+		}
+		remaining, holds := decideAbsent(condition.Conditions[0], fields)
+		if remaining == nil {
+			return nil, !holds
+		}
+		return &lib.Condition{Operator: lib.ConditionNot, Conditions: []lib.Condition{*remaining}}, false
+	}
+
+	if fieldIndex(fields, condition.Field) < 0 {
+		return nil, false
+	}
+	return &condition, false
+}
+
+// convertBranch converts a then or else schema that applies when condition holds, or always
+// if condition is nil. The properties it requires among fields become required on condition,
+// and its properties that are not among fields are returned as the fields it shows. Other
+// keywords refining fields already among fields are not represented, as a field has a single
+// set of validation rules.
+func (c *converter) convertBranch(schema *Schema, fields []lib.Field, condition *lib.Condition) ([]lib.Field, error) {
+	branch, release, err := c.effectiveSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	for _, name := range branch.Required {
+		requireWhen(fields, name, condition)
+	}
+
+	converted, err := c.convertPropertiesToFields(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]lib.Field, 0, len(converted))
+	for _, field := range converted {
+		if fieldIndex(fields, field.Name) < 0 {
+			result = append(result, field)
+		}
+	}
+	return result, nil
+}
+
+// buildCondition converts an if schema into a condition on the properties of the object it
+// applies to. Property const, enum, minimum/maximum (exclusive or not), required and
+// allOf/anyOf/oneOf/not combinations are understood; other keywords are ignored, and nil
+// is returned if nothing is understood. As in JSON Schema, a constraint on a property that
+// is not required also holds when the property is absent. oneOf is treated like anyOf.
+func (c *converter) buildCondition(schema *Schema) (*lib.Condition, error) {
+	schema, release, err := c.effectiveSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var parts []lib.Condition
+	constrained := make(map[string]bool)
+	for _, name := range schema.OrderedPropertyNames() {
+		condition, err := c.buildValueCondition(name, schema.Properties[name])
+		if err != nil {
+			return nil, err
+		}
+		if condition == nil {
+			continue
+		}
+		constrained[name] = true
+		if !contains(schema.Required, name) {
+			condition = &lib.Condition{Operator: lib.ConditionOr, Conditions: []lib.Condition{
+				{Operator: lib.ConditionNot, Conditions: []lib.Condition{{Operator: lib.ConditionPresent, Field: name}}},
+				*condition,
+			}}
+		}
+		parts = append(parts, *condition)
+	}
+
+	for _, name := range schema.Required {
+		if !constrained[name] {
+			parts = append(parts, lib.Condition{Operator: lib.ConditionPresent, Field: name})
+		}
+	}
+
+	alternatives, err := c.buildConditions(append(append([]*Schema(nil), schema.AnyOf...), schema.OneOf...), c.buildCondition)
+	if err != nil {
+		return nil, err
+	}
+	if alternative := combineConditions(lib.ConditionOr, alternatives); alternative != nil {
+		parts = append(parts, *alternative)
+	}
+
+	if schema.Not != nil {
+		negated, err := c.buildCondition(schema.Not)
+		if err != nil {
+			return nil, err
+		}
+		if negated != nil {
+			parts = append(parts, lib.Condition{Operator: lib.ConditionNot, Conditions: []lib.Condition{*negated}})
+		}
+	}
+
+	return combineConditions(lib.ConditionAnd, parts), nil
+}
+
+// buildValueCondition converts the schema of a property inside an if schema into a
+// condition on the property's value, or nil if none of its keywords are understood
+func (c *converter) buildValueCondition(name string, schema *Schema) (*lib.Condition, error) {
+	schema, release, err := c.effectiveSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var parts []lib.Condition
+
+	if schema.Const != nil {
+		parts = append(parts, lib.Condition{Operator: lib.ConditionEquals, Field: name, Value: schema.Const})
+	}
+	if len(schema.Enum) == 1 {
+		parts = append(parts, lib.Condition{Operator: lib.ConditionEquals, Field: name, Value: schema.Enum[0]})
+	} else if len(schema.Enum) > 1 {
+		parts = append(parts, lib.Condition{Operator: lib.ConditionIn, Field: name, Values: schema.Enum})
+	}
+
+	if schema.Minimum != nil || schema.Maximum != nil || schema.ExclusiveMinimum != nil || schema.ExclusiveMaximum != nil {
+		condition := lib.Condition{Operator: lib.ConditionRange, Field: name, Min: schema.Minimum, Max: schema.Maximum}
+		if schema.ExclusiveMinimum != nil && (condition.Min == nil || *schema.ExclusiveMinimum >= *condition.Min) {
+			condition.Min, condition.ExclusiveMin = schema.ExclusiveMinimum, true
+		}
+		if schema.ExclusiveMaximum != nil && (condition.Max == nil || *schema.ExclusiveMaximum <= *condition.Max) {
+			condition.Max, condition.ExclusiveMax = schema.ExclusiveMaximum, true
+		}
+		parts = append(parts, condition)
+	}
+
+	valueCondition := func(schema *Schema) (*lib.Condition, error) {
+		return c.buildValueCondition(name, schema)
+	}
+	alternatives, err := c.buildConditions(append(append([]*Schema(nil), schema.AnyOf...), schema.OneOf...), valueCondition)
+	if err != nil {
+		return nil, err
+	}
+	if alternative := combineConditions(lib.ConditionOr, alternatives); alternative != nil {
+		parts = append(parts, *alternative)
+	}
+
+	if schema.Not != nil {
+		negated, err := valueCondition(schema.Not)
+		if err != nil {
+			return nil, err
+		}
+		if negated != nil {
+			parts = append(parts, lib.Condition{Operator: lib.ConditionNot, Conditions: []lib.Condition{*negated}})
+		}
+	}
+
+	return combineConditions(lib.ConditionAnd, parts), nil
+}
+
+// buildConditions converts each of schemas with build. If any of them cannot be
+// converted, no conditions are returned: an alternative that is not understood could
+// be the one that holds.
+func (c *converter) buildConditions(schemas []*Schema, build func(*Schema) (*lib.Condition, error)) ([]lib.Condition, error) {
+	conditions := make([]lib.Condition, 0, len(schemas))
+	for _, schema := range schemas {
+		condition, err := build(schema)
+		if err != nil {
+			return nil, err
+		}
+		if condition == nil {
+			return nil, nil
+		}
+		conditions = append(conditions, *condition)
+	}
+	return conditions, nil
+}
+
+// combineConditions combines conditions with an and/or operator, returning nil for no
+// conditions and a single condition as is
+func combineConditions(operator lib.ConditionOperator, conditions []lib.Condition) *lib.Condition {
+	switch len(conditions) {
+	case 0:
+		return nil
+	case 1:
+		return &conditions[0]
+	}
+	return &lib.Condition{Operator: operator, Conditions: conditions}
+}
+
+// fieldIndex returns the index of the field with the given name, or -1
+func fieldIndex(fields []lib.Field, name string) int {
+	for i, field := range fields {
+		if field.Name == name {
+			return i
+		}
+	}
+	return -1
+}
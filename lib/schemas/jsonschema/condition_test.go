@@ -0,0 +1,253 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+func TestConvertSchemaToForm_Conditionals(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		check func(*testing.T, *lib.Form)
+	}{
+		{
+			name: "const with then and else",
+			input: `{
+				"properties": {
+					"country": {"type": "string"},
+					"postal_code": {"type": "string"}
+				},
+				"if": {"properties": {"country": {"const": "US"}}, "required": ["country"]},
+				"then": {"properties": {"state": {"type": "string"}, "postal_code": {"pattern": "[0-9]{5}"}}},
+				"else": {"properties": {"region": {"type": "string"}}}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				conditional := form.Fields[0].Conditional
+				if conditional == nil {
+					t.Fatalf("country has no conditional")
+				}
+				assertCondition(t, conditional.When, `{"operator":"equals","field":"country","value":"US"}`)
+				if got := fieldNames(conditional.Then); got != "state" {
+					t.Errorf("then fields = %s, want state", got)
+				}
+				if got := fieldNames(conditional.Else); got != "region" {
+					t.Errorf("else fields = %s, want region", got)
+				}
+			},
+		},
+		{
+			name: "optional property also holds when absent",
+			input: `{
+				"properties": {"plan": {"type": "string"}},
+				"if": {"properties": {"plan": {"enum": ["pro", "team"]}}},
+				"then": {"properties": {"seats": {"type": "integer"}}}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				assertCondition(t, form.Fields[0].Conditional.When,
+					`{"operator":"or","conditions":[{"operator":"not","conditions":[{"operator":"present","field":"plan"}]},{"operator":"in","field":"plan","values":["pro","team"]}]}`)
+			},
+		},
+		{
+			name: "range and required attach to the last tested field",
+			input: `{
+				"properties": {
+					"age": {"type": "integer"},
+					"email": {"type": "string"},
+					"name": {"type": "string"}
+				},
+				"if": {
+					"properties": {"age": {"minimum": 18, "exclusiveMaximum": 65}},
+					"required": ["age", "email"]
+				},
+				"then": {"properties": {"license": {"type": "string"}}}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				if form.Fields[0].Conditional != nil || form.Fields[2].Conditional != nil {
+					t.Errorf("conditional attached to age or name, want email")
+				}
+				assertCondition(t, form.Fields[1].Conditional.When,
+					`{"operator":"and","conditions":[{"operator":"range","field":"age","min":18,"max":65,"exclusiveMax":true},{"operator":"present","field":"email"}]}`)
+			},
+		},
+		{
+			name: "boolean combinations",
+			input: `{
+				"properties": {
+					"kind": {"type": "string"},
+					"count": {"type": "integer"}
+				},
+				"if": {
+					"anyOf": [
+						{"properties": {"kind": {"const": "bulk"}}, "required": ["kind"]},
+						{"properties": {"count": {"not": {"maximum": 10}}}, "required": ["count"]}
+					]
+				},
+				"then": {"properties": {"discount": {"type": "number"}}}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				assertCondition(t, form.Fields[1].Conditional.When,
+					`{"operator":"or","conditions":[{"operator":"equals","field":"kind","value":"bulk"},{"operator":"not","conditions":[{"operator":"range","field":"count","max":10}]}]}`)
+			},
+		},
+		{
+			name: "required of then and else",
+			input: `{
+				"properties": {
+					"country": {"type": "string"},
+					"state": {"type": "string"},
+					"province": {"type": "string"}
+				},
+				"if": {"properties": {"country": {"const": "US"}}, "required": ["country"]},
+				"then": {"required": ["state"]},
+				"else": {"required": ["province"]}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				if form.Fields[0].Conditional != nil {
+					t.Errorf("country has a conditional, want none as the branches show no fields")
+				}
+				assertCondition(t, form.Fields[1].Validation.RequiredWhen,
+					`{"operator":"equals","field":"country","value":"US"}`)
+				assertCondition(t, form.Fields[2].Validation.RequiredWhen,
+					`{"operator":"not","conditions":[{"operator":"equals","field":"country","value":"US"}]}`)
+
+				errs := form.ValidateValues(map[string]any{"country": "US"})
+				if len(errs) != 1 || errs["state"] == "" {
+					t.Errorf("ValidateValues(US) = %v, want only state required", errs)
+				}
+				errs = form.ValidateValues(map[string]any{"country": "CA"})
+				if len(errs) != 1 || errs["province"] == "" {
+					t.Errorf("ValidateValues(CA) = %v, want only province required", errs)
+				}
+			},
+		},
+		{
+			name: "conditionals on the same field are all kept",
+			input: `{
+				"properties": {
+					"plan": {"type": "string"},
+					"name": {"type": "string"}
+				},
+				"dependentSchemas": {"plan": {"properties": {"billing": {"type": "string"}}}},
+				"if": {"properties": {"plan": {"const": "team"}}, "required": ["plan"]},
+				"then": {"properties": {"seats": {"type": "integer"}}}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				if got := fieldNames(form.Fields); got != "plan,name" {
+					t.Fatalf("fields = %s, want plan,name", got)
+				}
+				if got := fieldNames(form.Fields[0].Conditional.Then); got != "billing" {
+					t.Errorf("plan then fields = %s, want billing", got)
+				}
+				if len(form.Fields[0].Conditionals) != 1 {
+					t.Fatalf("plan carries %d further conditionals, want 1", len(form.Fields[0].Conditionals))
+				}
+				if got := fieldNames(form.Fields[0].Conditionals[0].Then); got != "seats" {
+					t.Errorf("second conditional then fields = %s, want seats", got)
+				}
+			},
+		},
+		{
+			name: "condition on a property without a field that fails",
+			input: `{
+				"properties": {"name": {"type": "string"}},
+				"if": {"properties": {"mode": {"const": "advanced"}}, "required": ["mode"]},
+				"then": {"properties": {"level": {"type": "integer"}}},
+				"else": {"properties": {"nickname": {"type": "string"}}, "required": ["name"]}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				if got := fieldNames(form.Fields); got != "name,nickname" {
+					t.Errorf("fields = %s, want name,nickname as else always applies", got)
+				}
+				if form.Fields[0].Conditional != nil || !form.Fields[0].Validation.Required {
+					t.Errorf("name = %+v, want always required without a conditional", form.Fields[0])
+				}
+			},
+		},
+		{
+			name: "optional property without a field holds",
+			input: `{
+				"properties": {"a": {}},
+				"if": {"properties": {"b": {"const": 1}}},
+				"then": {"required": ["a"]}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				if validation := form.Fields[0].Validation; validation == nil || !validation.Required {
+					t.Errorf("a validation = %+v, want always required", validation)
+				}
+			},
+		},
+		{
+			name: "required property without a field fails",
+			input: `{
+				"properties": {"a": {}},
+				"if": {"required": ["b"]},
+				"then": {"required": ["a"]}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				if validation := form.Fields[0].Validation; validation != nil && (validation.Required || validation.RequiredWhen != nil) {
+					t.Errorf("a validation = %+v, want not required", validation)
+				}
+			},
+		},
+		{
+			name: "parts testing a property without a field are dropped",
+			input: `{
+				"properties": {"plan": {"type": "string"}},
+				"if": {
+					"properties": {"plan": {"const": "team"}, "mode": {"const": "advanced"}},
+					"required": ["plan"]
+				},
+				"then": {"properties": {"seats": {"type": "integer"}}}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				assertCondition(t, form.Fields[0].Conditional.When, `{"operator":"equals","field":"plan","value":"team"}`)
+			},
+		},
+		{
+			name: "if without understood keywords is dropped",
+			input: `{
+				"properties": {"name": {"type": "string"}},
+				"if": {"properties": {"name": {"minLength": 3}}},
+				"then": {"properties": {"nickname": {"type": "string"}}}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				if form.Fields[0].Conditional != nil {
+					t.Errorf("name has a conditional, want none")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			got, err := ConvertSchemaToForm(schema)
+			if err != nil {
+				t.Fatalf("ConvertSchemaToForm() error = %v", err)
+			}
+			if err := got.Validate(); err != nil {
+				t.Fatalf("Form.Validate() error = %v", err)
+			}
+			tt.check(t, got)
+		})
+	}
+}
+
+// assertCondition compares a condition to its expected JSON encoding
+func assertCondition(t *testing.T, condition *lib.Condition, want string) {
+	t.Helper()
+	data, err := json.Marshal(condition)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(data) != want {
+		t.Errorf("condition = %s, want %s", data, want)
+	}
+}
@@ -6,29 +6,47 @@ import (
 	"github.com/Olian04/form-from-schema/lib"
 )
 
-// ConvertSchemaToForm converts a JSON Schema to a Form structure
+// ConvertSchemaToForm converts a JSON Schema to a Form structure with the default Options
 func ConvertSchemaToForm(schema *Schema) (*lib.Form, error) {
+	return Options{}.Convert(schema)
+}
+
+// convertSchema converts a JSON Schema to a Form structure according to opts, as completed by withDefaults
+func convertSchema(schema *Schema, opts Options) (*lib.Form, error) {
 	if schema == nil {
 		return nil, fmt.Errorf("schema cannot be nil")
 	}
 
+	c := &converter{
+		opts:      opts,
+		refs:      newResolver(schema),
+		resolving: make(map[*Schema]bool),
+	}
+
+	schema, _, err := c.effectiveSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
 	form := &lib.Form{
 		Title:       schema.Title,
 		Description: schema.Description,
-		Method:      "POST", // Default
+		Action:      opts.Action,
+		Method:      opts.Method,
+		Naming:      opts.Naming,
 		Fields:      []lib.Field{},
 	}
 
 	// Handle object schemas with properties
 	if schema.Properties != nil {
-		fields, err := convertPropertiesToFields(schema.Properties, schema.Required)
+		fields, err := c.convertPropertiesToFields(schema)
 		if err != nil {
 			return nil, err
 		}
 		form.Fields = fields
 	} else {
 		// Handle single field schemas
-		field, err := convertSchemaToField("", schema)
+		field, err := c.convertSchemaToField("", schema)
 		if err != nil {
 			return nil, err
 		}
@@ -40,16 +58,59 @@ func ConvertSchemaToForm(schema *Schema) (*lib.Form, error) {
 	return form, nil
 }
 
-// convertPropertiesToFields converts schema properties to form fields
-func convertPropertiesToFields(properties map[string]*Schema, required []string) ([]lib.Field, error) {
+// converter holds the state shared by a single schema to form conversion
+type converter struct {
+	opts      Options
+	refs      *resolver
+	resolving map[*Schema]bool // Referenced schemas currently being converted, used to detect cycles
+}
+
+// effectiveSchema resolves $ref and merges allOf, returning the schema to convert and
+// a function that ends cycle detection for the schemas it referenced
+func (c *converter) effectiveSchema(schema *Schema) (*Schema, func(), error) {
+	var entered []*Schema
+	release := func() {
+		for _, target := range entered {
+			delete(c.resolving, target)
+		}
+	}
+
+	effective, err := c.effective(schema, &entered)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	return effective, release, nil
+}
+
+// effective resolves and merges schema, recording referenced schemas in entered
+func (c *converter) effective(schema *Schema, entered *[]*Schema) (*Schema, error) {
+	resolved, target, err := c.refs.resolve(schema)
+	if err != nil {
+		return nil, err
+	}
+	if target != nil {
+		if c.resolving[target] {
+			return nil, fmt.Errorf("circular $ref %q", referenceOf(schema))
+		}
+		c.resolving[target] = true
+		*entered = append(*entered, target)
+	}
+
+	return c.mergeAllOf(resolved, entered)
+}
+
+// convertPropertiesToFields converts schema properties to form fields in display order
+func (c *converter) convertPropertiesToFields(schema *Schema) ([]lib.Field, error) {
 	requiredMap := make(map[string]bool)
-	for _, req := range required {
+	for _, req := range schema.Required {
 		requiredMap[req] = true
 	}
 
-	fields := make([]lib.Field, 0, len(properties))
-	for name, propSchema := range properties {
-		field, err := convertSchemaToField(name, propSchema)
+	fields := make([]lib.Field, 0, len(schema.Properties))
+	for _, name := range schema.OrderedPropertyNames() {
+		propSchema := schema.Properties[name]
+		field, err := c.convertSchemaToField(name, propSchema)
 		if err != nil {
 			return nil, fmt.Errorf("error converting field %s: %w", name, err)
 		}
@@ -64,15 +125,38 @@ func convertPropertiesToFields(properties map[string]*Schema, required []string)
 		}
 	}
 
+	// Handle dependentRequired and dependentSchemas
+	if len(schema.DependentRequired) > 0 || len(schema.DependentSchemas) > 0 {
+		var err error
+		if fields, err = c.attachDependencies(schema, fields); err != nil {
+			return nil, err
+		}
+	}
+
+	// Handle conditional fields (if/then/else)
+	if schema.If != nil {
+		var err error
+		if fields, err = c.attachConditional(schema, fields); err != nil {
+			return nil, err
+		}
+	}
+
 	return fields, nil
 }
 
 // convertSchemaToField converts a single schema to a form field
-func convertSchemaToField(name string, schema *Schema) (*lib.Field, error) {
+func (c *converter) convertSchemaToField(name string, schema *Schema) (*lib.Field, error) {
 	if schema == nil {
 		return nil, nil
 	}
 
+	// Convert the referenced and allOf-merged schema in place of the declared one
+	schema, release, err := c.effectiveSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	field := &lib.Field{
 		Name:        name,
 		Label:       schema.Title,
@@ -81,18 +165,39 @@ func convertSchemaToField(name string, schema *Schema) (*lib.Field, error) {
 		ReadOnly:    schema.ReadOnly != nil && *schema.ReadOnly,
 		Deprecated:  schema.Deprecated != nil && *schema.Deprecated,
 	}
+	if field.Label == "" && name != "" {
+		field.Label = c.opts.Label(name)
+	}
+
+	// Handle oneOf/anyOf - one variant per alternative
+	if alternatives := variantAlternatives(schema); len(alternatives) > 0 {
+		if len(alternatives) > 1 {
+			if err := c.buildVariants(field, schema, alternatives); err != nil {
+				return nil, err
+			}
+			return field, nil
+		}
+		// A single non-null alternative only makes the value optional
+		alternative, release, err := c.effectiveSchema(alternatives[0])
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		schema = mergeSchemas(withoutAlternatives(schema), alternative)
+	}
 
 	// Determine field type
-	fieldType, err := determineFieldType(schema)
+	fieldType, err := c.determineFieldType(schema)
 	if err != nil {
 		return nil, err
 	}
 	field.Type = fieldType
+	field.DataType = determineDataType(schema)
 
 	// Handle enum/const - convert to select or radio
 	if len(schema.Enum) > 0 {
-		field.Options = convertEnumToOptions(schema.Enum)
-		if len(field.Options) <= 3 {
+		field.Options = c.convertEnumToOptions(schema.Enum)
+		if len(field.Options) <= c.opts.RadioThreshold {
 			field.Type = lib.FieldTypeRadio
 		} else {
 			field.Type = lib.FieldTypeSelect
@@ -103,11 +208,11 @@ func convertSchemaToField(name string, schema *Schema) (*lib.Field, error) {
 	}
 
 	// Build validation rules
-	field.Validation = buildValidation(schema)
+	field.Validation = c.buildValidation(schema)
 
 	// Handle object type - nested fields
 	if fieldType == lib.FieldTypeObject && schema.Properties != nil {
-		nestedFields, err := convertPropertiesToFields(schema.Properties, schema.Required)
+		nestedFields, err := c.convertPropertiesToFields(schema)
 		if err != nil {
 			return nil, err
 		}
@@ -117,7 +222,7 @@ func convertSchemaToField(name string, schema *Schema) (*lib.Field, error) {
 	// Handle array type
 	if fieldType == lib.FieldTypeArray {
 		if schema.Items != nil {
-			itemField, err := convertSchemaToField("item", schema.Items)
+			itemField, err := c.convertSchemaToField(c.opts.ItemName, schema.Items)
 			if err != nil {
 				return nil, err
 			}
@@ -139,22 +244,11 @@ func convertSchemaToField(name string, schema *Schema) (*lib.Field, error) {
 		}
 	}
 
-	// Handle conditional fields (if/then/else)
-	if schema.If != nil {
-		conditional, err := buildConditionalField(schema)
-		if err != nil {
-			return nil, err
-		}
-		if conditional != nil {
-			field.Conditional = conditional
-		}
-	}
-
 	return field, nil
 }
 
 // determineFieldType determines the HTML field type from the schema
-func determineFieldType(schema *Schema) (lib.FieldType, error) {
+func (c *converter) determineFieldType(schema *Schema) (lib.FieldType, error) {
 	typeStr, typeArray, hasType := schema.GetType()
 
 	if !hasType {
@@ -173,17 +267,65 @@ func determineFieldType(schema *Schema) (lib.FieldType, error) {
 		// For union types, prefer the first non-null type
 		for _, t := range typeArray {
 			if t != "null" {
-				return mapJSONTypeToFieldType(t, schema)
+				return c.mapJSONTypeToFieldType(t, schema)
 			}
 		}
 		return lib.FieldTypeText, nil
 	}
 
-	return mapJSONTypeToFieldType(typeStr, schema)
+	return c.mapJSONTypeToFieldType(typeStr, schema)
+}
+
+// determineDataType determines the JSON type of the value the field submits
+func determineDataType(schema *Schema) lib.DataType {
+	typeStr, typeArray, _ := schema.GetType()
+	for _, t := range typeArray {
+		if t != "null" {
+			typeStr = t
+			break
+		}
+	}
+
+	switch typeStr {
+	case "string":
+		return lib.DataTypeString
+	case "number":
+		return lib.DataTypeNumber
+	case "integer":
+		return lib.DataTypeInteger
+	case "boolean":
+		return lib.DataTypeBoolean
+	case "array":
+		return lib.DataTypeArray
+	case "object":
+		return lib.DataTypeObject
+	}
+
+	// Infer from enum/const values or structural keywords when no type is declared
+	sample := schema.Const
+	if len(schema.Enum) > 0 {
+		sample = schema.Enum[0]
+	}
+	switch sample.(type) {
+	case string:
+		return lib.DataTypeString
+	case float64, int, int64:
+		return lib.DataTypeNumber
+	case bool:
+		return lib.DataTypeBoolean
+	}
+	if schema.Properties != nil {
+		return lib.DataTypeObject
+	}
+	if schema.Items != nil {
+		return lib.DataTypeArray
+	}
+
+	return ""
 }
 
 // mapJSONTypeToFieldType maps JSON Schema types to HTML field types
-func mapJSONTypeToFieldType(jsonType string, schema *Schema) (lib.FieldType, error) {
+func (c *converter) mapJSONTypeToFieldType(jsonType string, schema *Schema) (lib.FieldType, error) {
 	switch jsonType {
 	case "string":
 		// Check format for more specific types
@@ -202,7 +344,7 @@ func mapJSONTypeToFieldType(jsonType string, schema *Schema) (lib.FieldType, err
 			return lib.FieldTypePassword, nil
 		default:
 			// Check if it's a long text field (textarea)
-			if schema.MaxLength != nil && *schema.MaxLength > 100 {
+			if schema.MaxLength != nil && *schema.MaxLength > c.opts.TextareaThreshold {
 				return lib.FieldTypeTextarea, nil
 			}
 			return lib.FieldTypeText, nil
@@ -222,12 +364,13 @@ func mapJSONTypeToFieldType(jsonType string, schema *Schema) (lib.FieldType, err
 	}
 }
 
-// convertEnumToOptions converts enum values to Option structs
-func convertEnumToOptions(enum []any) []lib.Option {
+// convertEnumToOptions converts enum values to Option structs, labeled by lib.ValueLabels
+func (c *converter) convertEnumToOptions(enum []any) []lib.Option {
+	labels := lib.ValueLabels(enum, c.opts.Label)
 	options := make([]lib.Option, 0, len(enum))
-	for _, value := range enum {
+	for i, value := range enum {
 		options = append(options, lib.Option{
-			Label: fmt.Sprintf("%v", value),
+			Label: labels[i],
 			Value: value,
 		})
 	}
@@ -235,7 +378,7 @@ func convertEnumToOptions(enum []any) []lib.Option {
 }
 
 // buildValidation builds validation rules from schema
-func buildValidation(schema *Schema) *lib.Validation {
+func (c *converter) buildValidation(schema *Schema) *lib.Validation {
 	if schema == nil {
 		return nil
 	}
@@ -251,21 +394,21 @@ func buildValidation(schema *Schema) *lib.Validation {
 	}
 	if schema.Pattern != "" {
 		validation.Pattern = schema.Pattern
-		validation.PatternError = "Invalid format"
+		validation.PatternError = c.opts.PatternError
 	}
 
-	// Number validations
+	// Number validations, keeping the tighter of an inclusive and an exclusive bound
 	if schema.Minimum != nil {
 		validation.Min = schema.Minimum
 	}
 	if schema.Maximum != nil {
 		validation.Max = schema.Maximum
 	}
-	if schema.ExclusiveMinimum != nil {
-		validation.Min = schema.ExclusiveMinimum
+	if schema.ExclusiveMinimum != nil && (validation.Min == nil || *schema.ExclusiveMinimum >= *validation.Min) {
+		validation.Min, validation.ExclusiveMin = schema.ExclusiveMinimum, true
 	}
-	if schema.ExclusiveMaximum != nil {
-		validation.Max = schema.ExclusiveMaximum
+	if schema.ExclusiveMaximum != nil && (validation.Max == nil || *schema.ExclusiveMaximum <= *validation.Max) {
+		validation.Max, validation.ExclusiveMax = schema.ExclusiveMaximum, true
 	}
 	if schema.MultipleOf != nil {
 		validation.Step = schema.MultipleOf
@@ -280,46 +423,3 @@ func buildValidation(schema *Schema) *lib.Validation {
 
 	return validation
 }
-
-// buildConditionalField builds conditional field logic from if/then/else
-func buildConditionalField(schema *Schema) (*lib.ConditionalField, error) {
-	if schema.If == nil {
-		return nil, nil
-	}
-
-	conditional := &lib.ConditionalField{}
-
-	// Try to extract condition from If schema
-	// This is a simplified version - full implementation would need to parse the condition
-	if schema.If.Properties != nil {
-		// Extract first property as condition field
-		for name := range schema.If.Properties {
-			conditional.Condition = name
-			break
-		}
-	}
-
-	// Convert Then fields
-	if schema.Then != nil {
-		thenFields, err := convertPropertiesToFields(schema.Then.Properties, schema.Then.Required)
-		if err != nil {
-			return nil, err
-		}
-		conditional.Then = thenFields
-	}
-
-	// Convert Else fields
-	if schema.Else != nil {
-		elseFields, err := convertPropertiesToFields(schema.Else.Properties, schema.Else.Required)
-		if err != nil {
-			return nil, err
-		}
-		conditional.Else = elseFields
-	}
-
-	if conditional.Condition == "" {
-		return nil, nil
-	}
-
-	return conditional, nil
-}
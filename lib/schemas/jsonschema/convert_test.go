@@ -2,6 +2,7 @@ package jsonschema
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/Olian04/form-from-schema/lib"
@@ -332,6 +333,19 @@ func TestConvertSchemaToForm_Validation(t *testing.T) {
 					v.Max != nil && *v.Max == 100
 			},
 		},
+		{
+			name: "tighter of inclusive and exclusive bounds",
+			schema: &Schema{
+				Type:             json.RawMessage(`"number"`),
+				Minimum:          floatPtr(5),
+				ExclusiveMinimum: floatPtr(0),
+				Maximum:          floatPtr(100),
+				ExclusiveMaximum: floatPtr(100),
+			},
+			check: func(v *lib.Validation) bool {
+				return v != nil && *v.Min == 5 && !v.ExclusiveMin && *v.Max == 100 && v.ExclusiveMax
+			},
+		},
 		{
 			name: "pattern validation",
 			schema: &Schema{
@@ -360,8 +374,8 @@ func TestConvertSchemaToForm_Validation(t *testing.T) {
 				ExclusiveMaximum: floatPtr(100),
 			},
 			check: func(v *lib.Validation) bool {
-				return v != nil && v.Min != nil && *v.Min == 0 &&
-					v.Max != nil && *v.Max == 100
+				return v != nil && v.Min != nil && *v.Min == 0 && v.ExclusiveMin &&
+					v.Max != nil && *v.Max == 100 && v.ExclusiveMax
 			},
 		},
 		{
@@ -552,3 +566,159 @@ func intPtr(i int) *int {
 func floatPtr(f float64) *float64 {
 	return &f
 }
+
+func TestConvertSchemaToForm_FieldOrder(t *testing.T) {
+	input := `{
+		"type": "object",
+		"properties": {
+			"username": {"type": "string"},
+			"email": {"type": "string", "format": "email"},
+			"address": {
+				"type": "object",
+				"ui:order": ["zip", "*"],
+				"properties": {
+					"street": {"type": "string"},
+					"city": {"type": "string"},
+					"zip": {"type": "string"}
+				}
+			},
+			"age": {"type": "integer"}
+		}
+	}`
+
+	schema, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	// Conversion must produce the same order every time
+	for i := 0; i < 10; i++ {
+		form, err := ConvertSchemaToForm(schema)
+		if err != nil {
+			t.Fatalf("ConvertSchemaToForm() error = %v", err)
+		}
+
+		var names []string
+		for _, field := range form.Fields {
+			names = append(names, field.Name)
+		}
+		if got := strings.Join(names, ","); got != "username,email,address,age" {
+			t.Fatalf("ConvertSchemaToForm() field order = %s, want username,email,address,age", got)
+		}
+
+		var nested []string
+		for _, field := range form.Fields[2].Fields {
+			nested = append(nested, field.Name)
+		}
+		if got := strings.Join(nested, ","); got != "zip,street,city" {
+			t.Fatalf("ConvertSchemaToForm() nested field order = %s, want zip,street,city", got)
+		}
+	}
+}
+
+func TestConvertSchemaToForm_DataTypes(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *Schema
+		want   lib.DataType
+	}{
+		{name: "string", schema: &Schema{Type: json.RawMessage(`"string"`)}, want: lib.DataTypeString},
+		{name: "integer", schema: &Schema{Type: json.RawMessage(`"integer"`)}, want: lib.DataTypeInteger},
+		{name: "number", schema: &Schema{Type: json.RawMessage(`"number"`)}, want: lib.DataTypeNumber},
+		{name: "boolean", schema: &Schema{Type: json.RawMessage(`"boolean"`)}, want: lib.DataTypeBoolean},
+		{name: "nullable integer", schema: &Schema{Type: json.RawMessage(`["null", "integer"]`)}, want: lib.DataTypeInteger},
+		{name: "untyped numeric enum", schema: &Schema{Enum: []any{1.0, 2.0}}, want: lib.DataTypeNumber},
+		{name: "untyped string const", schema: &Schema{Const: "v1"}, want: lib.DataTypeString},
+		{name: "untyped", schema: &Schema{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form, err := ConvertSchemaToForm(tt.schema)
+			if err != nil {
+				t.Fatalf("ConvertSchemaToForm() error = %v", err)
+			}
+			if got := form.Fields[0].DataType; got != tt.want {
+				t.Errorf("ConvertSchemaToForm() field DataType = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertSchemaToForm_Labels(t *testing.T) {
+	schema, err := Parse([]byte(`{
+		"properties": {
+			"first_name": {"type": "string"},
+			"dateOfBirth": {"type": "string", "format": "date", "title": "Born on"},
+			"homeAddress": {"properties": {"postalCode": {"type": "string"}, "countryID": {"type": "string"}}},
+			"phoneNumbers": {"type": "array", "items": {"type": "string"}},
+			"status": {"enum": ["in_progress", "done", 3]},
+			"payment": {"oneOf": [
+				{"properties": {"method": {"const": "credit_card"}, "cardNumber": {"type": "string"}}},
+				{"properties": {"method": {"const": "invoice"}}}
+			]},
+			"operator": {"enum": ["+", "-", "*", "/"]},
+			"size": {"enum": ["1-5", "1_5", "6+"]},
+			"file": {"oneOf": [
+				{"properties": {"type": {"const": "image/png"}}},
+				{"properties": {"type": {"const": "text/plain"}}}
+			]}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		label func(string) string
+		want  []string
+	}{
+		{
+			name: "humanized",
+			want: []string{
+				"First name", "Born on", "Home address", "Postal code", "Country ID", "Phone numbers", "Item",
+				"Status", "In progress", "Done", "3", "Payment", "Credit card", "Card number", "Invoice",
+				"Operator", "+", "-", "*", "/", "Size", "1-5", "1_5", "6+", "File", "image/png", "text/plain",
+			},
+		},
+		{
+			name:  "custom dictionary",
+			label: lib.Labeler{Words: map[string]string{"id": "identifier", "in": "IN"}}.Label,
+			want: []string{
+				"First name", "Born on", "Home address", "Postal code", "Country identifier", "Phone numbers", "Item",
+				"Status", "IN progress", "Done", "3", "Payment", "Credit card", "Card number", "Invoice",
+				"Operator", "+", "-", "*", "/", "Size", "1-5", "1_5", "6+", "File", "image/png", "text/plain",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form, err := Options{Label: tt.label}.Convert(schema)
+			if err != nil {
+				t.Fatalf("Options.Convert() error = %v", err)
+			}
+			if got := collectLabels(form.Fields); strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("labels = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// collectLabels lists the labels of fields, their options, variants and nested fields in order
+func collectLabels(fields []lib.Field) []string {
+	var labels []string
+	for _, field := range fields {
+		labels = append(labels, field.Label)
+		for _, option := range field.Options {
+			labels = append(labels, option.Label)
+		}
+		for _, variant := range field.Variants {
+			labels = append(labels, variant.Label)
+			labels = append(labels, collectLabels(variant.Fields)...)
+		}
+		labels = append(labels, collectLabels(field.Fields)...)
+	}
+	return labels
+}
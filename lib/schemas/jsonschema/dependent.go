@@ -0,0 +1,61 @@
+package jsonschema
+
+import (
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+// attachDependencies converts the dependentRequired and dependentSchemas of an object schema.
+// A property listed in dependentRequired, or required by a dependent schema, becomes
+// required once the property it depends on is present. The other properties of a dependent
+// schema are shown by a conditional attached to the field they depend on. Dependencies on
+// properties without a field are dropped, as those properties are never submitted.
+func (c *converter) attachDependencies(schema *Schema, fields []lib.Field) ([]lib.Field, error) {
+	for i := range fields {
+		present := lib.Condition{Operator: lib.ConditionPresent, Field: fields[i].Name}
+
+		for _, name := range schema.DependentRequired[fields[i].Name] {
+			requireWhen(fields, name, &present)
+		}
+
+		dependent := schema.DependentSchemas[fields[i].Name]
+		if dependent == nil {
+			continue
+		}
+
+		thenFields, err := c.convertBranch(dependent, fields, &present)
+		if err != nil {
+			return nil, err
+		}
+		if len(thenFields) > 0 {
+			attachConditionalField(&fields[i], &lib.ConditionalField{When: &present, Then: thenFields})
+		}
+	}
+	return fields, nil
+}
+
+// requireWhen makes the field with the given name required when condition holds, in
+// addition to any other condition it is already required on, or always if condition is nil
+func requireWhen(fields []lib.Field, name string, condition *lib.Condition) {
+	index := fieldIndex(fields, name)
+	if index < 0 {
+		return
+	}
+
+	field := &fields[index]
+	if field.Validation == nil {
+		field.Validation = &lib.Validation{}
+	}
+	validation := field.Validation
+
+	switch {
+	case validation.Required:
+		// Always required already
+	case condition == nil:
+		validation.Required = true
+		validation.RequiredWhen = nil
+	case validation.RequiredWhen == nil:
+		validation.RequiredWhen = condition
+	default:
+		validation.RequiredWhen = combineConditions(lib.ConditionOr, []lib.Condition{*validation.RequiredWhen, *condition})
+	}
+}
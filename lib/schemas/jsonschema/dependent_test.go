@@ -0,0 +1,134 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+func TestConvertSchemaToForm_Dependencies(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		check func(*testing.T, *lib.Form)
+	}{
+		{
+			name: "dependentRequired",
+			input: `{
+				"properties": {
+					"name": {"type": "string"},
+					"billing_address": {"type": "string"},
+					"credit_card": {"type": "string"}
+				},
+				"required": ["name"],
+				"dependentRequired": {
+					"credit_card": ["billing_address", "name"],
+					"unknown": ["billing_address"]
+				}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				name, address := form.Fields[0], form.Fields[1]
+				if name.Validation.RequiredWhen != nil {
+					t.Errorf("name RequiredWhen set, want it to stay always required")
+				}
+				if address.Validation == nil || address.Validation.Required {
+					t.Fatalf("billing_address validation = %+v, want conditionally required", address.Validation)
+				}
+				assertCondition(t, address.Validation.RequiredWhen, `{"operator":"present","field":"credit_card"}`)
+			},
+		},
+		{
+			name: "required by several properties",
+			input: `{
+				"properties": {
+					"email": {"type": "string"},
+					"phone": {"type": "string"},
+					"name": {"type": "string"}
+				},
+				"dependentRequired": {"email": ["name"], "phone": ["name"]}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				assertCondition(t, form.Fields[2].Validation.RequiredWhen,
+					`{"operator":"or","conditions":[{"operator":"present","field":"email"},{"operator":"present","field":"phone"}]}`)
+			},
+		},
+		{
+			name: "dependentSchemas",
+			input: `{
+				"properties": {
+					"credit_card": {"type": "string"},
+					"name": {"type": "string"}
+				},
+				"dependentSchemas": {
+					"credit_card": {
+						"properties": {
+							"billing_address": {"type": "string"},
+							"name": {"minLength": 2}
+						},
+						"required": ["billing_address", "name"]
+					}
+				}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				conditional := form.Fields[0].Conditional
+				if conditional == nil {
+					t.Fatalf("credit_card has no conditional")
+				}
+				assertCondition(t, conditional.When, `{"operator":"present","field":"credit_card"}`)
+				if got := fieldNames(conditional.Then); got != "billing_address" {
+					t.Fatalf("then fields = %s, want billing_address", got)
+				}
+				if !conditional.Then[0].Validation.Required {
+					t.Errorf("billing_address should be required when shown")
+				}
+				assertCondition(t, form.Fields[1].Validation.RequiredWhen, `{"operator":"present","field":"credit_card"}`)
+			},
+		},
+		{
+			name: "dependent schema next to a conditional",
+			input: `{
+				"properties": {
+					"credit_card": {"type": "string"},
+					"country": {"type": "string"}
+				},
+				"dependentSchemas": {
+					"credit_card": {"properties": {"billing_address": {"type": "string"}}}
+				},
+				"if": {"properties": {"credit_card": {"const": "amex"}}, "required": ["credit_card"]},
+				"then": {"properties": {"cid": {"type": "string"}}}
+			}`,
+			check: func(t *testing.T, form *lib.Form) {
+				if got := fieldNames(form.Fields); got != "credit_card,country" {
+					t.Fatalf("fields = %s, want credit_card,country", got)
+				}
+				if then := form.Fields[0].Conditional.Then; fieldNames(then) != "billing_address" {
+					t.Errorf("credit_card conditional shows %s, want billing_address", fieldNames(then))
+				}
+				if len(form.Fields[0].Conditionals) != 1 {
+					t.Fatalf("credit_card carries %d further conditionals, want 1", len(form.Fields[0].Conditionals))
+				}
+				if then := form.Fields[0].Conditionals[0].Then; fieldNames(then) != "cid" {
+					t.Errorf("second conditional shows %s, want cid", fieldNames(then))
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			got, err := ConvertSchemaToForm(schema)
+			if err != nil {
+				t.Fatalf("ConvertSchemaToForm() error = %v", err)
+			}
+			if err := got.Validate(); err != nil {
+				t.Fatalf("Form.Validate() error = %v", err)
+			}
+			tt.check(t, got)
+		})
+	}
+}
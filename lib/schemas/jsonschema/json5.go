@@ -0,0 +1,503 @@
+package jsonschema
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// json5Parser parses JSON, JSONC and JSON5 documents into YAML nodes, so they are
+// converted like YAML documents and their values keep their positions. Lines and columns
+// are 1-based, and columns are counted in characters.
+type json5Parser struct {
+	data   []byte
+	offset int
+	line   int
+	column int
+}
+
+// parseJSON5 parses a JSON5 document into the YAML node of its single value. Syntax errors
+// are YAMLErrors pointing to the offending character.
+func parseJSON5(data []byte) (*yaml.Node, error) {
+	p := newJSON5Parser(data)
+	if err := p.skipSpace(); err != nil {
+		return nil, err
+	}
+	node, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.skipSpace(); err != nil {
+		return nil, err
+	}
+	if p.offset < len(p.data) {
+		return nil, p.errorf("unexpected %s after the document", p.describe())
+	}
+	return node, nil
+}
+
+// newJSON5Parser returns a parser at the start of data
+func newJSON5Parser(data []byte) *json5Parser {
+	return &json5Parser{data: data, line: 1, column: 1}
+}
+
+// peek returns the character at the current offset and its size, or a size of 0 at the end
+// of the document
+func (p *json5Parser) peek() (rune, int) {
+	if p.offset >= len(p.data) {
+		return 0, 0
+	}
+	return utf8.DecodeRune(p.data[p.offset:])
+}
+
+// advance moves past the character at the current offset
+func (p *json5Parser) advance() {
+	r, size := p.peek()
+	p.offset += size
+	if r == '\n' || r == '\u2028' || r == '\u2029' || (r == '\r' && !p.at('\n')) {
+		p.line++
+		p.column = 1
+	} else {
+		p.column++
+	}
+}
+
+// at reports whether the current character is r
+func (p *json5Parser) at(r rune) bool {
+	next, size := p.peek()
+	return size > 0 && next == r
+}
+
+// errorf returns a YAMLError at the current position
+func (p *json5Parser) errorf(format string, args ...any) *YAMLError {
+	return &YAMLError{Line: p.line, Column: p.column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// describe describes the current character for error messages
+func (p *json5Parser) describe() string {
+	r, size := p.peek()
+	switch {
+	case size == 0:
+		return "end of document"
+	case r == utf8.RuneError && size == 1:
+		return "invalid UTF-8"
+	}
+	return fmt.Sprintf("character %q", r)
+}
+
+// skipSpace moves past white space and comments
+func (p *json5Parser) skipSpace() error {
+	for {
+		r, size := p.peek()
+		switch {
+		case size == 0:
+			return nil
+		case unicode.IsSpace(r) || r == '\uFEFF':
+			p.advance()
+		case r == '/' && p.offset+1 < len(p.data) && p.data[p.offset+1] == '/':
+			for size > 0 && r != '\n' && r != '\r' && r != '\u2028' && r != '\u2029' {
+				p.advance()
+				r, size = p.peek()
+			}
+		case r == '/' && p.offset+1 < len(p.data) && p.data[p.offset+1] == '*':
+			start := *p
+			p.advance()
+			p.advance()
+			for !bytes.HasPrefix(p.data[p.offset:], []byte("*/")) {
+				if p.offset >= len(p.data) {
+					return start.errorf("unterminated comment")
+				}
+				p.advance()
+			}
+			p.advance()
+			p.advance()
+		default:
+			return nil
+		}
+	}
+}
+
+// node returns a node of the given kind and tag at the current position
+func (p *json5Parser) node(kind yaml.Kind, tag string) *yaml.Node {
+	return &yaml.Node{Kind: kind, Tag: tag, Line: p.line, Column: p.column}
+}
+
+// value parses a single value
+func (p *json5Parser) value() (*yaml.Node, error) {
+	r, size := p.peek()
+	switch {
+	case size == 0:
+		return nil, p.errorf("unexpected end of document")
+	case r == '{':
+		return p.object()
+	case r == '[':
+		return p.array()
+	case r == '"' || r == '\'':
+		node := p.node(yaml.ScalarNode, "!!str")
+		node.Style = yaml.DoubleQuotedStyle
+		value, err := p.string()
+		node.Value = value
+		return node, err
+	case r == '-' || r == '+' || r == '.' || (r >= '0' && r <= '9'):
+		return p.number()
+	case isIdentifierStart(r):
+		node := p.node(yaml.ScalarNode, "")
+		switch word := p.identifier(); word {
+		case "true", "false":
+			node.Tag, node.Value = "!!bool", word
+		case "null":
+			node.Tag, node.Value = "!!null", word
+		case "Infinity", "NaN":
+			return nil, &YAMLError{Line: node.Line, Column: node.Column, Msg: word + " cannot be represented in JSON"}
+		default:
+			return nil, &YAMLError{Line: node.Line, Column: node.Column, Msg: fmt.Sprintf("unexpected identifier %s", word)}
+		}
+		return node, nil
+	}
+	return nil, p.errorf("unexpected %s", p.describe())
+}
+
+// object parses an object into a mapping node
+func (p *json5Parser) object() (*yaml.Node, error) {
+	node := p.node(yaml.MappingNode, "!!map")
+	node.Style = yaml.FlowStyle
+	p.advance()
+
+	for {
+		if err := p.skipSpace(); err != nil {
+			return nil, err
+		}
+		if p.at('}') {
+			p.advance()
+			return node, nil
+		}
+
+		key, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.skipSpace(); err != nil {
+			return nil, err
+		}
+		if !p.at(':') {
+			return nil, p.errorf("expected ':' after object key, found %s", p.describe())
+		}
+		p.advance()
+		if err := p.skipSpace(); err != nil {
+			return nil, err
+		}
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, key, value)
+
+		if err := p.skipSpace(); err != nil {
+			return nil, err
+		}
+		switch {
+		case p.at(','):
+			p.advance()
+		case p.at('}'):
+			p.advance()
+			return node, nil
+		default:
+			return nil, p.errorf("expected ',' or '}' in object, found %s", p.describe())
+		}
+	}
+}
+
+// key parses an object key: a string or an identifier
+func (p *json5Parser) key() (*yaml.Node, error) {
+	node := p.node(yaml.ScalarNode, "!!str")
+	r, _ := p.peek()
+	switch {
+	case r == '"' || r == '\'':
+		value, err := p.string()
+		node.Value = value
+		return node, err
+	case isIdentifierStart(r):
+		node.Value = p.identifier()
+		return node, nil
+	}
+	return nil, p.errorf("expected object key, found %s", p.describe())
+}
+
+// array parses an array into a sequence node
+func (p *json5Parser) array() (*yaml.Node, error) {
+	node := p.node(yaml.SequenceNode, "!!seq")
+	node.Style = yaml.FlowStyle
+	p.advance()
+
+	for {
+		if err := p.skipSpace(); err != nil {
+			return nil, err
+		}
+		if p.at(']') {
+			p.advance()
+			return node, nil
+		}
+
+		item, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, item)
+
+		if err := p.skipSpace(); err != nil {
+			return nil, err
+		}
+		switch {
+		case p.at(','):
+			p.advance()
+		case p.at(']'):
+			p.advance()
+			return node, nil
+		default:
+			return nil, p.errorf("expected ',' or ']' in array, found %s", p.describe())
+		}
+	}
+}
+
+// string parses a double- or single-quoted string, resolving its escapes
+func (p *json5Parser) string() (string, error) {
+	start := *p
+	quote, _ := p.peek()
+	p.advance()
+
+	var value strings.Builder
+	for {
+		r, size := p.peek()
+		switch {
+		case size == 0:
+			return "", start.errorf("unterminated string")
+		case r == utf8.RuneError && size == 1:
+			return "", p.errorf("invalid UTF-8 in string")
+		case r == quote:
+			p.advance()
+			return value.String(), nil
+		case r == '\n' || r == '\r':
+			return "", p.errorf("line break in string")
+		case r == '\\':
+			if err := p.escape(&value); err != nil {
+				return "", err
+			}
+		default:
+			value.WriteRune(r)
+			p.advance()
+		}
+	}
+}
+
+// escape parses an escape sequence in a string, writing the character it stands for
+func (p *json5Parser) escape(value *strings.Builder) error {
+	start := *p
+	p.advance()
+	r, size := p.peek()
+	if size == 0 {
+		return start.errorf("unterminated string")
+	}
+	p.advance()
+
+	switch r {
+	case 'b':
+		value.WriteByte('\b')
+	case 'f':
+		value.WriteByte('\f')
+	case 'n':
+		value.WriteByte('\n')
+	case 'r':
+		value.WriteByte('\r')
+	case 't':
+		value.WriteByte('\t')
+	case 'v':
+		value.WriteByte('\v')
+	case '0':
+		if next, _ := p.peek(); next >= '0' && next <= '9' {
+			return start.errorf("invalid escape sequence \\0%c", next)
+		}
+		value.WriteByte(0)
+	case 'x':
+		code, err := p.hex(2)
+		if err != nil {
+			return start.errorf("invalid escape sequence: %v", err)
+		}
+		value.WriteRune(rune(code))
+	case 'u':
+		code, err := p.hex(4)
+		if err != nil {
+			return start.errorf("invalid escape sequence: %v", err)
+		}
+		char := rune(code)
+		if utf16.IsSurrogate(char) && bytes.HasPrefix(p.data[p.offset:], []byte(`\u`)) {
+			low := *p
+			p.advance()
+			p.advance()
+			next, err := p.hex(4)
+			if err != nil {
+				return low.errorf("invalid escape sequence: %v", err)
+			}
+			if pair := utf16.DecodeRune(char, rune(next)); pair != utf8.RuneError {
+				char = pair
+			} else {
+				*p = low
+			}
+		}
+		value.WriteRune(char)
+	case '\r':
+		if p.at('\n') {
+			p.advance()
+		}
+	case '\n', '\u2028', '\u2029':
+		// Line continuation
+	default:
+		if r >= '1' && r <= '9' {
+			return start.errorf("invalid escape sequence \\%c", r)
+		}
+		value.WriteRune(r)
+	}
+	return nil
+}
+
+// hex parses the given number of hexadecimal digits
+func (p *json5Parser) hex(digits int) (uint64, error) {
+	end := p.offset + digits
+	if end > len(p.data) {
+		return 0, fmt.Errorf("expected %d hexadecimal digits", digits)
+	}
+	code, err := strconv.ParseUint(string(p.data[p.offset:end]), 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("expected %d hexadecimal digits", digits)
+	}
+	for range digits {
+		p.advance()
+	}
+	return code, nil
+}
+
+// number parses a decimal or hexadecimal number with an optional sign into an integer or
+// float node
+func (p *json5Parser) number() (*yaml.Node, error) {
+	node := p.node(yaml.ScalarNode, "!!int")
+	start := p.offset
+
+	sign := ""
+	if p.at('-') || p.at('+') {
+		if p.at('-') {
+			sign = "-"
+		}
+		p.advance()
+	}
+
+	if r, _ := p.peek(); isIdentifierStart(r) {
+		word := p.identifier()
+		if word == "Infinity" || word == "NaN" {
+			return nil, &YAMLError{Line: node.Line, Column: node.Column, Msg: string(p.data[start:p.offset]) + " cannot be represented in JSON"}
+		}
+		return nil, &YAMLError{Line: node.Line, Column: node.Column, Msg: fmt.Sprintf("invalid number %s", p.data[start:p.offset])}
+	}
+
+	if p.at('0') && p.offset+1 < len(p.data) && (p.data[p.offset+1] == 'x' || p.data[p.offset+1] == 'X') {
+		p.advance()
+		p.advance()
+		digits := p.offset
+		for p.offset < len(p.data) && isHexDigit(p.data[p.offset]) {
+			p.advance()
+		}
+		magnitude, err := strconv.ParseUint(string(p.data[digits:p.offset]), 16, 64)
+		if err != nil {
+			return nil, &YAMLError{Line: node.Line, Column: node.Column, Msg: fmt.Sprintf("invalid number %s", p.data[start:p.offset])}
+		}
+		node.Value = sign + strconv.FormatUint(magnitude, 10)
+		if sign == "-" && magnitude > 1<<63 {
+			node.Tag, node.Value = "!!float", strconv.FormatFloat(-float64(magnitude), 'g', -1, 64)
+		}
+		return node, nil
+	}
+
+	integer := p.digits()
+	if len(integer) > 1 && integer[0] == '0' {
+		return nil, &YAMLError{Line: node.Line, Column: node.Column, Msg: fmt.Sprintf("invalid number %s: leading zero", p.data[start:p.offset])}
+	}
+	fraction := ""
+	if p.at('.') {
+		p.advance()
+		fraction = p.digits()
+		node.Tag = "!!float"
+	}
+	if integer == "" && fraction == "" {
+		return nil, &YAMLError{Line: node.Line, Column: node.Column, Msg: fmt.Sprintf("invalid number %s", p.data[start:p.offset])}
+	}
+	if p.at('e') || p.at('E') {
+		p.advance()
+		if p.at('-') || p.at('+') {
+			p.advance()
+		}
+		if p.digits() == "" {
+			return nil, &YAMLError{Line: node.Line, Column: node.Column, Msg: fmt.Sprintf("invalid number %s: missing exponent", p.data[start:p.offset])}
+		}
+		node.Tag = "!!float"
+	}
+
+	literal := strings.TrimPrefix(string(p.data[start:p.offset]), "+")
+	if node.Tag == "!!int" {
+		if _, err := strconv.ParseInt(literal, 10, 64); err == nil {
+			node.Value = literal
+			return node, nil
+		}
+		if _, err := strconv.ParseUint(literal, 10, 64); err == nil {
+			node.Value = literal
+			return node, nil
+		}
+		node.Tag = "!!float"
+	}
+	value, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		return nil, &YAMLError{Line: node.Line, Column: node.Column, Msg: fmt.Sprintf("invalid number %s", p.data[start:p.offset])}
+	}
+	node.Value = strconv.FormatFloat(value, 'g', -1, 64)
+	return node, nil
+}
+
+// digits parses a run of decimal digits
+func (p *json5Parser) digits() string {
+	start := p.offset
+	for p.offset < len(p.data) && p.data[p.offset] >= '0' && p.data[p.offset] <= '9' {
+		p.advance()
+	}
+	return string(p.data[start:p.offset])
+}
+
+// identifier parses an identifier, such as an unquoted key or a keyword
+func (p *json5Parser) identifier() string {
+	start := p.offset
+	for {
+		r, size := p.peek()
+		if size == 0 || !isIdentifierPart(r) {
+			return string(p.data[start:p.offset])
+		}
+		p.advance()
+	}
+}
+
+// isIdentifierStart reports whether r can start an identifier
+func isIdentifierStart(r rune) bool {
+	return r == '$' || r == '_' || unicode.IsLetter(r)
+}
+
+// isIdentifierPart reports whether r can continue an identifier
+func isIdentifierPart(r rune) bool {
+	return isIdentifierStart(r) || unicode.IsDigit(r) || unicode.In(r, unicode.Mn, unicode.Mc, unicode.Pc) ||
+		r == '\u200C' || r == '\u200D'
+}
+
+// isHexDigit reports whether c is a hexadecimal digit
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,239 @@
+package jsonschema
+
+import (
+	"reflect"
+)
+
+// mergeAllOf returns the single effective schema described by schema and its allOf
+// subschemas. Subschemas are resolved and flattened recursively before being merged.
+func (c *converter) mergeAllOf(schema *Schema, entered *[]*Schema) (*Schema, error) {
+	if len(schema.AllOf) == 0 {
+		return schema, nil
+	}
+
+	merged := *schema
+	merged.AllOf = nil
+	result := &merged
+
+	start := len(*entered)
+	for _, sub := range schema.AllOf {
+		// Schemas referenced by earlier siblings are not on the path to this one, so a base
+		// they share with it (diamond inheritance) is not taken for a cycle. They count as
+		// being converted again once it is merged.
+		siblings := (*entered)[start:]
+		for _, target := range siblings {
+			delete(c.resolving, target)
+		}
+		part, err := c.effective(sub, entered)
+		for _, target := range siblings {
+			c.resolving[target] = true
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = mergeSchemas(result, part)
+	}
+
+	return result, nil
+}
+
+// mergeSchemas merges other into a copy of base the way allOf combines them:
+// properties, required and dependencies are unioned, numeric, length and item
+// bounds are intersected and enums are intersected. For annotations and keywords
+// that cannot be combined (title, format, pattern, if/then/else, ...) base wins.
+// Properties and items defined by both are combined lazily with a nested allOf.
+func mergeSchemas(base, other *Schema) *Schema {
+	merged := *base
+
+	// Type and annotations
+	if len(merged.Type) == 0 {
+		merged.Type = other.Type
+	}
+	if merged.Title == "" {
+		merged.Title = other.Title
+	}
+	if merged.Description == "" {
+		merged.Description = other.Description
+	}
+	if merged.Default == nil {
+		merged.Default = other.Default
+	}
+	if merged.Const == nil {
+		merged.Const = other.Const
+	}
+	if merged.Format == "" {
+		merged.Format = other.Format
+	}
+	if merged.Pattern == "" {
+		merged.Pattern = other.Pattern
+	}
+	if merged.MultipleOf == nil {
+		merged.MultipleOf = other.MultipleOf
+	}
+	if other.ReadOnly != nil && *other.ReadOnly {
+		merged.ReadOnly = other.ReadOnly
+	}
+	if other.Deprecated != nil && *other.Deprecated {
+		merged.Deprecated = other.Deprecated
+	}
+	if merged.XOrder == nil {
+		merged.XOrder = other.XOrder
+	}
+	if len(merged.UIOrder) == 0 {
+		merged.UIOrder = other.UIOrder
+	}
+
+	// Keywords that cannot be combined
+	if merged.If == nil {
+		merged.If, merged.Then, merged.Else = other.If, other.Then, other.Else
+	}
+	if merged.Not == nil {
+		merged.Not = other.Not
+	}
+	if len(merged.AnyOf) == 0 {
+		merged.AnyOf = other.AnyOf
+	}
+	if len(merged.OneOf) == 0 {
+		merged.OneOf = other.OneOf
+	}
+
+	// Enums are intersected
+	if len(other.Enum) > 0 {
+		if len(merged.Enum) == 0 {
+			merged.Enum = other.Enum
+		} else {
+			merged.Enum = intersectValues(merged.Enum, other.Enum)
+		}
+	}
+
+	// Bounds are intersected
+	merged.Minimum = maxFloat(merged.Minimum, other.Minimum)
+	merged.ExclusiveMinimum = maxFloat(merged.ExclusiveMinimum, other.ExclusiveMinimum)
+	merged.Maximum = minFloat(merged.Maximum, other.Maximum)
+	merged.ExclusiveMaximum = minFloat(merged.ExclusiveMaximum, other.ExclusiveMaximum)
+	merged.MinLength = maxInt(merged.MinLength, other.MinLength)
+	merged.MaxLength = minInt(merged.MaxLength, other.MaxLength)
+	merged.MinItems = maxInt(merged.MinItems, other.MinItems)
+	merged.MaxItems = minInt(merged.MaxItems, other.MaxItems)
+	merged.MinProperties = maxInt(merged.MinProperties, other.MinProperties)
+	merged.MaxProperties = minInt(merged.MaxProperties, other.MaxProperties)
+
+	// Properties are unioned, keeping base's order followed by the new properties
+	if len(other.Properties) > 0 {
+		order := base.documentOrder()
+		properties := make(map[string]*Schema, len(base.Properties)+len(other.Properties))
+		for name, prop := range base.Properties {
+			properties[name] = prop
+		}
+		for _, name := range other.documentOrder() {
+			prop := other.Properties[name]
+			if existing, ok := properties[name]; ok {
+				properties[name] = &Schema{AllOf: []*Schema{existing, prop}}
+				continue
+			}
+			properties[name] = prop
+			order = append(order, name)
+		}
+		merged.Properties = properties
+		merged.PropertyOrder = order
+	}
+
+	merged.Required = unionStrings(merged.Required, other.Required)
+
+	if len(other.DependentRequired) > 0 {
+		dependentRequired := make(map[string][]string, len(base.DependentRequired)+len(other.DependentRequired))
+		for name, required := range base.DependentRequired {
+			dependentRequired[name] = required
+		}
+		for name, required := range other.DependentRequired {
+			dependentRequired[name] = unionStrings(dependentRequired[name], required)
+		}
+		merged.DependentRequired = dependentRequired
+	}
+
+	if len(other.DependentSchemas) > 0 {
+		dependentSchemas := make(map[string]*Schema, len(base.DependentSchemas)+len(other.DependentSchemas))
+		for name, dependent := range base.DependentSchemas {
+			dependentSchemas[name] = dependent
+		}
+		for name, dependent := range other.DependentSchemas {
+			if existing, ok := dependentSchemas[name]; ok {
+				dependentSchemas[name] = &Schema{AllOf: []*Schema{existing, dependent}}
+				continue
+			}
+			dependentSchemas[name] = dependent
+		}
+		merged.DependentSchemas = dependentSchemas
+	}
+
+	// Items defined by both must satisfy both
+	if other.Items != nil {
+		if merged.Items == nil {
+			merged.Items = other.Items
+		} else {
+			merged.Items = &Schema{AllOf: []*Schema{merged.Items, other.Items}}
+		}
+	}
+
+	return &merged
+}
+
+// intersectValues returns the values of a that are also in b
+func intersectValues(a, b []any) []any {
+	result := []any{}
+	for _, value := range a {
+		for _, candidate := range b {
+			if reflect.DeepEqual(value, candidate) {
+				result = append(result, value)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// unionStrings returns the strings of a followed by those of b not already in a
+func unionStrings(a, b []string) []string {
+	if len(b) == 0 {
+		return a
+	}
+	result := append([]string(nil), a...)
+	for _, s := range b {
+		if !contains(result, s) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// maxFloat returns the larger of two optional bounds
+func maxFloat(a, b *float64) *float64 {
+	if a == nil || (b != nil && *b > *a) {
+		return b
+	}
+	return a
+}
+
+// minFloat returns the smaller of two optional bounds
+func minFloat(a, b *float64) *float64 {
+	if a == nil || (b != nil && *b < *a) {
+		return b
+	}
+	return a
+}
+
+// maxInt returns the larger of two optional bounds
+func maxInt(a, b *int) *int {
+	if a == nil || (b != nil && *b > *a) {
+		return b
+	}
+	return a
+}
+
+// minInt returns the smaller of two optional bounds
+func minInt(a, b *int) *int {
+	if a == nil || (b != nil && *b < *a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,239 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+func TestConvertSchemaToForm_AllOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+		check   func(*testing.T, *lib.Form)
+	}{
+		{
+			name: "properties and required are unioned",
+			input: `{
+				"allOf": [
+					{"$ref": "#/$defs/entity"},
+					{"properties": {"name": {"type": "string"}}, "required": ["name"]}
+				],
+				"properties": {"notes": {"type": "string"}},
+				"$defs": {
+					"entity": {
+						"type": "object",
+						"properties": {"id": {"type": "integer"}, "created": {"type": "string", "format": "date"}},
+						"required": ["id"]
+					}
+				}
+			}`,
+			check: func(t *testing.T, f *lib.Form) {
+				if got := fieldNames(f.Fields); got != "notes,id,created,name" {
+					t.Errorf("fields = %s, want notes,id,created,name", got)
+				}
+				for _, field := range f.Fields {
+					required := field.Validation != nil && field.Validation.Required
+					if wantRequired := field.Name == "id" || field.Name == "name"; required != wantRequired {
+						t.Errorf("field %s required = %v, want %v", field.Name, required, wantRequired)
+					}
+				}
+			},
+		},
+		{
+			name: "bounds are intersected",
+			input: `{
+				"properties": {
+					"age": {
+						"type": "integer",
+						"minimum": 0,
+						"maximum": 150,
+						"allOf": [{"minimum": 18}, {"maximum": 200}]
+					},
+					"code": {
+						"type": "string",
+						"allOf": [{"minLength": 2, "maxLength": 10}, {"minLength": 4, "maxLength": 8}]
+					}
+				}
+			}`,
+			check: func(t *testing.T, f *lib.Form) {
+				age := f.Fields[0].Validation
+				if age == nil || *age.Min != 18 || *age.Max != 150 {
+					t.Errorf("age validation = %+v, want min 18 and max 150", age)
+				}
+				code := f.Fields[1].Validation
+				if code == nil || *code.MinLength != 4 || *code.MaxLength != 8 {
+					t.Errorf("code validation = %+v, want minLength 4 and maxLength 8", code)
+				}
+			},
+		},
+		{
+			name: "overlapping properties are merged",
+			input: `{
+				"allOf": [
+					{"properties": {"email": {"type": "string", "title": "Email"}}},
+					{"properties": {"email": {"format": "email", "maxLength": 254}}, "required": ["email"]}
+				]
+			}`,
+			check: func(t *testing.T, f *lib.Form) {
+				if len(f.Fields) != 1 {
+					t.Fatalf("got %d fields, want 1", len(f.Fields))
+				}
+				email := f.Fields[0]
+				if email.Type != lib.FieldTypeEmail || email.Label != "Email" {
+					t.Errorf("email field = %s/%q, want email/Email", email.Type, email.Label)
+				}
+				if email.Validation == nil || !email.Validation.Required || *email.Validation.MaxLength != 254 {
+					t.Errorf("email validation = %+v, want required with maxLength 254", email.Validation)
+				}
+			},
+		},
+		{
+			name: "enums are intersected",
+			input: `{
+				"properties": {
+					"size": {"allOf": [{"enum": ["s", "m", "l", "xl"]}, {"enum": ["m", "l", "xxl"]}]}
+				}
+			}`,
+			check: func(t *testing.T, f *lib.Form) {
+				size := f.Fields[0]
+				if size.Type != lib.FieldTypeRadio || len(size.Options) != 2 {
+					t.Errorf("size field = %s with %d options, want radio with 2 options", size.Type, len(size.Options))
+				}
+			},
+		},
+		{
+			name: "nested allOf and mixins",
+			input: `{
+				"allOf": [
+					{"allOf": [{"$ref": "#/$defs/timestamps"}, {"properties": {"id": {"type": "integer"}}}]},
+					{"title": "Article", "properties": {"body": {"type": "string", "maxLength": 5000}}}
+				],
+				"$defs": {
+					"timestamps": {"properties": {"updated": {"type": "string", "format": "date-time"}}}
+				}
+			}`,
+			check: func(t *testing.T, f *lib.Form) {
+				if f.Title != "Article" {
+					t.Errorf("form title = %q, want Article", f.Title)
+				}
+				if got := fieldNames(f.Fields); got != "updated,id,body" {
+					t.Errorf("fields = %s, want updated,id,body", got)
+				}
+			},
+		},
+		{
+			name: "allOf in nested object",
+			input: `{
+				"properties": {
+					"billing": {"allOf": [{"$ref": "#/$defs/address"}, {"required": ["street"]}]}
+				},
+				"$defs": {
+					"address": {"type": "object", "properties": {"street": {"type": "string"}}}
+				}
+			}`,
+			check: func(t *testing.T, f *lib.Form) {
+				billing := f.Fields[0]
+				if billing.Type != lib.FieldTypeObject || len(billing.Fields) != 1 {
+					t.Fatalf("billing = %s with %d fields, want object with 1 field", billing.Type, len(billing.Fields))
+				}
+				if street := billing.Fields[0]; street.Validation == nil || !street.Validation.Required {
+					t.Errorf("billing.street should be required")
+				}
+			},
+		},
+		{
+			name: "diamond allOf",
+			input: `{
+				"properties": {"a": {"$ref": "#/$defs/a"}},
+				"$defs": {
+					"a": {"allOf": [{"$ref": "#/$defs/b"}, {"$ref": "#/$defs/c"}]},
+					"b": {"allOf": [{"$ref": "#/$defs/entity"}], "properties": {"name": {"type": "string"}}},
+					"c": {"allOf": [{"$ref": "#/$defs/entity"}], "properties": {"size": {"type": "integer"}}},
+					"entity": {"type": "object", "properties": {"id": {"type": "string"}}, "required": ["id"]}
+				}
+			}`,
+			check: func(t *testing.T, f *lib.Form) {
+				a := f.Fields[0]
+				if a.Type != lib.FieldTypeObject || fieldNames(a.Fields) != "name,id,size" {
+					t.Fatalf("a = %s with fields %s, want object with name,id,size", a.Type, fieldNames(a.Fields))
+				}
+				if id := a.Fields[1]; id.Validation == nil || !id.Validation.Required {
+					t.Errorf("a.id should be required")
+				}
+			},
+		},
+		{
+			name: "circular allOf through a sibling",
+			input: `{
+				"properties": {"x": {"$ref": "#/$defs/a"}},
+				"$defs": {
+					"a": {"allOf": [{"$ref": "#/$defs/b"}, {"$ref": "#/$defs/c"}]},
+					"b": {"type": "object"},
+					"c": {"allOf": [{"$ref": "#/$defs/a"}]}
+				}
+			}`,
+			wantErr: "circular $ref",
+		},
+		{
+			name: "circular allOf",
+			input: `{
+				"properties": {"x": {"$ref": "#/$defs/a"}},
+				"$defs": {"a": {"allOf": [{"$ref": "#/$defs/a"}]}}
+			}`,
+			wantErr: "circular $ref",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			got, err := ConvertSchemaToForm(schema)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ConvertSchemaToForm() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConvertSchemaToForm() error = %v", err)
+			}
+			tt.check(t, got)
+		})
+	}
+}
+
+func TestMergeSchemas_DoesNotModifyInputs(t *testing.T) {
+	base := &Schema{
+		Properties: map[string]*Schema{"a": {}},
+		Required:   []string{"a"},
+	}
+	other := &Schema{
+		Properties: map[string]*Schema{"b": {}},
+		Required:   []string{"b"},
+	}
+
+	merged := mergeSchemas(base, other)
+
+	if len(merged.Properties) != 2 || len(merged.Required) != 2 {
+		t.Errorf("mergeSchemas() = %d properties and %d required, want 2 and 2", len(merged.Properties), len(merged.Required))
+	}
+	if len(base.Properties) != 1 || len(base.Required) != 1 {
+		t.Errorf("mergeSchemas() modified base schema")
+	}
+}
+
+// fieldNames joins the names of fields with commas
+func fieldNames(fields []lib.Field) string {
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,68 @@
+package jsonschema
+
+import (
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+// Defaults of the conversion options
+const (
+	DefaultMethod            = "POST"
+	DefaultRadioThreshold    = 3
+	DefaultTextareaThreshold = 100
+	DefaultPatternError      = "Invalid format"
+	DefaultItemName          = "item"
+)
+
+// Options configures the policy of schema to form conversion. Zero fields take their
+// defaults, so the zero value converts like ConvertSchemaToForm.
+type Options struct {
+	Method string           // Method the form submits with, DefaultMethod if empty
+	Action string           // URL the form submits to
+	Naming lib.NamingScheme // How the names of nested fields are qualified, dotted if empty
+
+	// RadioThreshold is the largest number of enum values shown as radio buttons rather than
+	// a select, DefaultRadioThreshold if 0. Use a negative threshold to always use selects.
+	RadioThreshold int
+
+	// TextareaThreshold is the maxLength above which strings are edited in a textarea rather
+	// than a text input, DefaultTextareaThreshold if 0. Use a negative threshold to make every
+	// string with a maxLength a textarea.
+	TextareaThreshold int
+
+	PatternError string // Message shown for values not matching their pattern, DefaultPatternError if empty
+	ItemName     string // Name of the field of an array's items, DefaultItemName if empty
+
+	// Label returns the label of a field without a title from its property name, or the
+	// ItemName for array items, and the labels of string enum values and of variants told
+	// apart by a discriminator without a title. lib.HumanizeLabel if nil; use a
+	// lib.Labeler for a dictionary of your own.
+	Label func(name string) string
+}
+
+// Convert converts a JSON Schema to a Form structure according to the options
+func (o Options) Convert(schema *Schema) (*lib.Form, error) {
+	return convertSchema(schema, o.withDefaults())
+}
+
+// withDefaults returns the options with zero fields set to their defaults
+func (o Options) withDefaults() Options {
+	if o.Method == "" {
+		o.Method = DefaultMethod
+	}
+	if o.RadioThreshold == 0 {
+		o.RadioThreshold = DefaultRadioThreshold
+	}
+	if o.TextareaThreshold == 0 {
+		o.TextareaThreshold = DefaultTextareaThreshold
+	}
+	if o.PatternError == "" {
+		o.PatternError = DefaultPatternError
+	}
+	if o.ItemName == "" {
+		o.ItemName = DefaultItemName
+	}
+	if o.Label == nil {
+		o.Label = lib.HumanizeLabel
+	}
+	return o
+}
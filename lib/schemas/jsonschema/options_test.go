@@ -0,0 +1,129 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+const optionsSchema = `{
+	"title": "Profile",
+	"properties": {
+		"role": {"enum": ["admin", "editor", "viewer"]},
+		"size": {"enum": ["s", "m", "l", "xl"]},
+		"bio": {"type": "string", "maxLength": 80},
+		"code": {"type": "string", "pattern": "^[A-Z]{3}$"},
+		"tags": {"type": "array", "items": {"type": "string"}},
+		"address": {"title": "Postal address", "properties": {"street": {"type": "string"}}}
+	}
+}`
+
+func TestOptions_Convert(t *testing.T) {
+	schema, err := Parse([]byte(optionsSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		opts  Options
+		check func(*testing.T, *lib.Form, map[string]lib.Field)
+	}{
+		{
+			name: "defaults",
+			opts: Options{},
+			check: func(t *testing.T, form *lib.Form, fields map[string]lib.Field) {
+				if form.Method != "POST" || form.Action != "" || form.Naming != "" {
+					t.Errorf("Method, Action, Naming = %q, %q, %q", form.Method, form.Action, form.Naming)
+				}
+				if fields["role"].Type != lib.FieldTypeRadio || fields["size"].Type != lib.FieldTypeSelect {
+					t.Errorf("role, size types = %s, %s, want radio, select", fields["role"].Type, fields["size"].Type)
+				}
+				if fields["bio"].Type != lib.FieldTypeText {
+					t.Errorf("bio type = %s, want text", fields["bio"].Type)
+				}
+				if fields["code"].Validation.PatternError != "Invalid format" {
+					t.Errorf("code pattern error = %q", fields["code"].Validation.PatternError)
+				}
+				if fields["tags"].Fields[0].Name != "item" {
+					t.Errorf("tags item name = %q, want item", fields["tags"].Fields[0].Name)
+				}
+				if fields["role"].Label != "Role" || fields["address"].Label != "Postal address" {
+					t.Errorf("role, address labels = %q, %q", fields["role"].Label, fields["address"].Label)
+				}
+			},
+		},
+		{
+			name: "form attributes",
+			opts: Options{Method: "PUT", Action: "/profile", Naming: lib.NamingBracket},
+			check: func(t *testing.T, form *lib.Form, _ map[string]lib.Field) {
+				if form.Method != "PUT" || form.Action != "/profile" || form.Naming != lib.NamingBracket {
+					t.Errorf("Method, Action, Naming = %q, %q, %q", form.Method, form.Action, form.Naming)
+				}
+			},
+		},
+		{
+			name: "thresholds",
+			opts: Options{RadioThreshold: 4, TextareaThreshold: 50},
+			check: func(t *testing.T, _ *lib.Form, fields map[string]lib.Field) {
+				if fields["size"].Type != lib.FieldTypeRadio {
+					t.Errorf("size type = %s, want radio", fields["size"].Type)
+				}
+				if fields["bio"].Type != lib.FieldTypeTextarea {
+					t.Errorf("bio type = %s, want textarea", fields["bio"].Type)
+				}
+			},
+		},
+		{
+			name: "always select",
+			opts: Options{RadioThreshold: -1},
+			check: func(t *testing.T, _ *lib.Form, fields map[string]lib.Field) {
+				if fields["role"].Type != lib.FieldTypeSelect {
+					t.Errorf("role type = %s, want select", fields["role"].Type)
+				}
+			},
+		},
+		{
+			name: "pattern error and item name",
+			opts: Options{PatternError: "Must be three capital letters", ItemName: "tag"},
+			check: func(t *testing.T, _ *lib.Form, fields map[string]lib.Field) {
+				if fields["code"].Validation.PatternError != "Must be three capital letters" {
+					t.Errorf("code pattern error = %q", fields["code"].Validation.PatternError)
+				}
+				if fields["tags"].Fields[0].Name != "tag" {
+					t.Errorf("tags item name = %q, want tag", fields["tags"].Fields[0].Name)
+				}
+			},
+		},
+		{
+			name: "label fallback",
+			opts: Options{Label: strings.ToUpper},
+			check: func(t *testing.T, _ *lib.Form, fields map[string]lib.Field) {
+				if fields["role"].Label != "ROLE" || fields["tags"].Fields[0].Label != "ITEM" {
+					t.Errorf("role, tags item labels = %q, %q", fields["role"].Label, fields["tags"].Fields[0].Label)
+				}
+				if fields["address"].Label != "Postal address" || fields["address"].Fields[0].Label != "STREET" {
+					t.Errorf("address, street labels = %q, %q", fields["address"].Label, fields["address"].Fields[0].Label)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form, err := tt.opts.Convert(schema)
+			if err != nil {
+				t.Fatalf("Options.Convert() error = %v", err)
+			}
+			if err := form.Validate(); err != nil {
+				t.Fatalf("Form.Validate() error = %v", err)
+			}
+			fields := make(map[string]lib.Field)
+			for _, field := range form.Fields {
+				fields[field.Name] = field
+			}
+			tt.check(t, form, fields)
+		})
+	}
+}
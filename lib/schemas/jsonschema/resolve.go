@@ -0,0 +1,289 @@
+package jsonschema
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// resolver resolves $ref and $dynamicRef keywords against the schema document
+// they were parsed from. Only references into the document itself are supported:
+// JSON Pointer fragments, $anchor names and URIs identified by an embedded $id.
+type resolver struct {
+	resources map[string]*Schema // Schema resources keyed by absolute URI (without fragment)
+	anchors   map[string]*Schema // Anchored schemas keyed by "<resource URI>#<anchor>"
+	baseURIs  map[*Schema]string // Base URI in effect for every schema in the document
+}
+
+// newResolver indexes the schema resources and anchors reachable from root
+func newResolver(root *Schema) *resolver {
+	r := &resolver{
+		resources: make(map[string]*Schema),
+		anchors:   make(map[string]*Schema),
+		baseURIs:  make(map[*Schema]string),
+	}
+	if root != nil && root.ID == "" {
+		// The root schema without an $id is addressed by the empty URI
+		r.resources[""] = root
+	}
+	r.index(root, "")
+	return r
+}
+
+// index records the base URI of schema and its subschemas, registering every
+// $id as a resource and every $anchor/$dynamicAnchor relative to that resource
+func (r *resolver) index(schema *Schema, base string) {
+	if schema == nil {
+		return
+	}
+	if _, seen := r.baseURIs[schema]; seen {
+		return
+	}
+
+	if schema.ID != "" {
+		base = resolveURI(base, schema.ID)
+		r.resources[stripFragment(base)] = schema
+	}
+	r.baseURIs[schema] = base
+
+	resource := stripFragment(base)
+	if schema.Anchor != "" {
+		r.anchors[resource+"#"+schema.Anchor] = schema
+	}
+	if schema.DynamicAnchor != "" {
+		r.anchors[resource+"#"+schema.DynamicAnchor] = schema
+	}
+
+	for _, sub := range subschemas(schema) {
+		r.index(sub, base)
+	}
+}
+
+// resolve follows schema's $ref (or $dynamicRef) chain and returns the referenced
+// schema. If schema has no reference it is returned as is. Annotations declared
+// next to the reference (title, description, default, readOnly, deprecated)
+// take precedence over those of the referenced schema.
+func (r *resolver) resolve(schema *Schema) (*Schema, *Schema, error) {
+	if schema == nil || (schema.Ref == "" && schema.DynamicRef == "") {
+		return schema, nil, nil
+	}
+
+	visited := make(map[*Schema]bool)
+	target := schema
+	for target.Ref != "" || target.DynamicRef != "" {
+		if visited[target] {
+			return nil, nil, fmt.Errorf("circular $ref %q", referenceOf(schema))
+		}
+		visited[target] = true
+
+		next, err := r.lookup(target, referenceOf(target))
+		if err != nil {
+			return nil, nil, err
+		}
+		target = next
+	}
+
+	resolved := *target
+	if schema.Title != "" {
+		resolved.Title = schema.Title
+	}
+	if schema.Description != "" {
+		resolved.Description = schema.Description
+	}
+	if schema.Default != nil {
+		resolved.Default = schema.Default
+	}
+	if schema.ReadOnly != nil {
+		resolved.ReadOnly = schema.ReadOnly
+	}
+	if schema.Deprecated != nil {
+		resolved.Deprecated = schema.Deprecated
+	}
+	r.baseURIs[&resolved] = r.baseURIs[target]
+
+	return &resolved, target, nil
+}
+
+// lookup resolves a single reference relative to the base URI of the schema it appears in
+func (r *resolver) lookup(from *Schema, ref string) (*Schema, error) {
+	uri := resolveURI(r.baseURIs[from], ref)
+	resourceURI, fragment, _ := strings.Cut(uri, "#")
+
+	resource, ok := r.resources[resourceURI]
+	if !ok {
+		return nil, fmt.Errorf("unresolvable $ref %q: unknown schema resource %q", ref, resourceURI)
+	}
+
+	if fragment == "" {
+		return resource, nil
+	}
+
+	if strings.HasPrefix(fragment, "/") {
+		target, err := walkPointer(resource, fragment)
+		if err != nil {
+			return nil, fmt.Errorf("unresolvable $ref %q: %w", ref, err)
+		}
+		return target, nil
+	}
+
+	target, ok := r.anchors[resourceURI+"#"+fragment]
+	if !ok {
+		return nil, fmt.Errorf("unresolvable $ref %q: unknown anchor %q", ref, fragment)
+	}
+	return target, nil
+}
+
+// walkPointer evaluates a JSON Pointer (RFC 6901) against a schema
+func walkPointer(schema *Schema, pointer string) (*Schema, error) {
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	current := schema
+
+	for i := 0; i < len(tokens); i++ {
+		keyword := unescapePointerToken(tokens[i])
+
+		var next *Schema
+		switch keyword {
+		case "items":
+			next = current.Items
+		case "not":
+			next = current.Not
+		case "if":
+			next = current.If
+		case "then":
+			next = current.Then
+		case "else":
+			next = current.Else
+		case "contains":
+			next = current.Contains
+		case "additionalProperties":
+			next = current.AdditionalProperties
+		case "propertyNames":
+			next = current.PropertyNames
+		case "unevaluatedItems":
+			next = current.UnevaluatedItems
+		case "unevaluatedProperties":
+			next = current.UnevaluatedProperties
+		case "contentSchema":
+			next = current.ContentSchema
+		case "$defs", "definitions", "properties", "patternProperties", "dependentSchemas":
+			if i+1 >= len(tokens) {
+				return nil, fmt.Errorf("pointer %q ends at keyword %q", pointer, keyword)
+			}
+			i++
+			next = schemaMap(current, keyword)[unescapePointerToken(tokens[i])]
+		case "allOf", "anyOf", "oneOf", "prefixItems":
+			if i+1 >= len(tokens) {
+				return nil, fmt.Errorf("pointer %q ends at keyword %q", pointer, keyword)
+			}
+			i++
+			index, err := strconv.Atoi(tokens[i])
+			if err != nil {
+				return nil, fmt.Errorf("pointer %q has invalid index %q", pointer, tokens[i])
+			}
+			list := schemaList(current, keyword)
+			if index >= 0 && index < len(list) {
+				next = list[index]
+			}
+		default:
+			return nil, fmt.Errorf("pointer %q references unsupported keyword %q", pointer, keyword)
+		}
+
+		if next == nil {
+			return nil, fmt.Errorf("pointer %q does not resolve to a schema", pointer)
+		}
+		current = next
+	}
+
+	return current, nil
+}
+
+// schemaMap returns the keyword's map of named subschemas
+func schemaMap(schema *Schema, keyword string) map[string]*Schema {
+	switch keyword {
+	case "$defs":
+		return schema.Defs
+	case "definitions":
+		return schema.Definitions
+	case "properties":
+		return schema.Properties
+	case "patternProperties":
+		return schema.PatternProperties
+	case "dependentSchemas":
+		return schema.DependentSchemas
+	}
+	return nil
+}
+
+// schemaList returns the keyword's list of subschemas
+func schemaList(schema *Schema, keyword string) []*Schema {
+	switch keyword {
+	case "allOf":
+		return schema.AllOf
+	case "anyOf":
+		return schema.AnyOf
+	case "oneOf":
+		return schema.OneOf
+	case "prefixItems":
+		return schema.PrefixItems
+	}
+	return nil
+}
+
+// subschemas returns all direct subschemas of schema
+func subschemas(schema *Schema) []*Schema {
+	subs := []*Schema{
+		schema.Not, schema.If, schema.Then, schema.Else, schema.Items,
+		schema.Contains, schema.AdditionalProperties, schema.PropertyNames,
+		schema.UnevaluatedItems, schema.UnevaluatedProperties, schema.ContentSchema,
+	}
+	subs = append(subs, schema.AllOf...)
+	subs = append(subs, schema.AnyOf...)
+	subs = append(subs, schema.OneOf...)
+	subs = append(subs, schema.PrefixItems...)
+	for _, defs := range []map[string]*Schema{
+		schema.Defs, schema.Definitions, schema.Properties,
+		schema.PatternProperties, schema.DependentSchemas,
+	} {
+		for _, sub := range defs {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// referenceOf returns the reference declared by schema, preferring $ref over $dynamicRef
+func referenceOf(schema *Schema) string {
+	if schema.Ref != "" {
+		return schema.Ref
+	}
+	return schema.DynamicRef
+}
+
+// resolveURI resolves ref against base, falling back to ref if either fails to parse
+func resolveURI(base, ref string) string {
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	return baseURL.ResolveReference(refURL).String()
+}
+
+// stripFragment removes the fragment from a URI
+func stripFragment(uri string) string {
+	resource, _, _ := strings.Cut(uri, "#")
+	return resource
+}
+
+// unescapePointerToken decodes a single JSON Pointer reference token
+func unescapePointerToken(token string) string {
+	if unescaped, err := url.PathUnescape(token); err == nil {
+		token = unescaped
+	}
+	token = strings.ReplaceAll(token, "~1", "/")
+	return strings.ReplaceAll(token, "~0", "~")
+}
@@ -0,0 +1,194 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+func TestConvertSchemaToForm_Refs(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+		check   func(*lib.Form) bool
+	}{
+		{
+			name: "local $defs pointer",
+			input: `{
+				"type": "object",
+				"properties": {
+					"billing": {"$ref": "#/$defs/address"},
+					"shipping": {"$ref": "#/$defs/address"}
+				},
+				"$defs": {
+					"address": {
+						"type": "object",
+						"properties": {
+							"street": {"type": "string"},
+							"city": {"type": "string"}
+						}
+					}
+				}
+			}`,
+			check: func(f *lib.Form) bool {
+				if len(f.Fields) != 2 {
+					return false
+				}
+				for _, field := range f.Fields {
+					if field.Type != lib.FieldTypeObject || len(field.Fields) != 2 {
+						return false
+					}
+				}
+				return true
+			},
+		},
+		{
+			name: "legacy definitions pointer",
+			input: `{
+				"properties": {"email": {"$ref": "#/definitions/email"}},
+				"definitions": {"email": {"type": "string", "format": "email"}}
+			}`,
+			check: func(f *lib.Form) bool {
+				return len(f.Fields) == 1 && f.Fields[0].Type == lib.FieldTypeEmail
+			},
+		},
+		{
+			name: "pointer with escaped tokens",
+			input: `{
+				"properties": {"value": {"$ref": "#/$defs/a~1b~0c"}},
+				"$defs": {"a/b~c": {"type": "integer"}}
+			}`,
+			check: func(f *lib.Form) bool {
+				return len(f.Fields) == 1 && f.Fields[0].Type == lib.FieldTypeNumber
+			},
+		},
+		{
+			name: "anchor reference",
+			input: `{
+				"properties": {"born": {"$ref": "#birthday"}},
+				"$defs": {"date": {"$anchor": "birthday", "type": "string", "format": "date"}}
+			}`,
+			check: func(f *lib.Form) bool {
+				return len(f.Fields) == 1 && f.Fields[0].Type == lib.FieldTypeDate
+			},
+		},
+		{
+			name: "dynamic anchor reference",
+			input: `{
+				"properties": {"born": {"$dynamicRef": "#birthday"}},
+				"$defs": {"date": {"$dynamicAnchor": "birthday", "type": "string", "format": "date"}}
+			}`,
+			check: func(f *lib.Form) bool {
+				return len(f.Fields) == 1 && f.Fields[0].Type == lib.FieldTypeDate
+			},
+		},
+		{
+			name: "$id relative reference",
+			input: `{
+				"$id": "https://example.com/schemas/user",
+				"properties": {"home": {"$ref": "address"}},
+				"$defs": {
+					"address": {
+						"$id": "address",
+						"type": "object",
+						"properties": {"zip": {"$ref": "#/$defs/zip"}},
+						"$defs": {"zip": {"type": "string", "pattern": "^[0-9]{5}$"}}
+					}
+				}
+			}`,
+			check: func(f *lib.Form) bool {
+				if len(f.Fields) != 1 || len(f.Fields[0].Fields) != 1 {
+					return false
+				}
+				zip := f.Fields[0].Fields[0]
+				return zip.Validation != nil && zip.Validation.Pattern == "^[0-9]{5}$"
+			},
+		},
+		{
+			name: "annotations next to $ref take precedence",
+			input: `{
+				"properties": {"name": {"$ref": "#/$defs/name", "title": "Full name"}},
+				"$defs": {"name": {"type": "string", "title": "Name", "description": "Your name"}}
+			}`,
+			check: func(f *lib.Form) bool {
+				return len(f.Fields) == 1 && f.Fields[0].Label == "Full name" && f.Fields[0].Description == "Your name"
+			},
+		},
+		{
+			name: "$ref chain",
+			input: `{
+				"properties": {"age": {"$ref": "#/$defs/a"}},
+				"$defs": {"a": {"$ref": "#/$defs/b"}, "b": {"type": "integer", "minimum": 0}}
+			}`,
+			check: func(f *lib.Form) bool {
+				return len(f.Fields) == 1 && f.Fields[0].Validation != nil && *f.Fields[0].Validation.Min == 0
+			},
+		},
+		{
+			name: "root $ref",
+			input: `{
+				"$ref": "#/$defs/user",
+				"$defs": {"user": {"title": "User", "properties": {"name": {"type": "string"}}}}
+			}`,
+			check: func(f *lib.Form) bool {
+				return f.Title == "User" && len(f.Fields) == 1 && f.Fields[0].Name == "name"
+			},
+		},
+		{
+			name: "circular $ref chain",
+			input: `{
+				"properties": {"loop": {"$ref": "#/$defs/a"}},
+				"$defs": {"a": {"$ref": "#/$defs/b"}, "b": {"$ref": "#/$defs/a"}}
+			}`,
+			wantErr: "circular $ref",
+		},
+		{
+			name: "recursive schema",
+			input: `{
+				"properties": {"node": {"$ref": "#/$defs/node"}},
+				"$defs": {"node": {"type": "object", "properties": {"child": {"$ref": "#/$defs/node"}}}}
+			}`,
+			wantErr: "circular $ref",
+		},
+		{
+			name:    "missing definition",
+			input:   `{"properties": {"x": {"$ref": "#/$defs/missing"}}}`,
+			wantErr: "unresolvable $ref",
+		},
+		{
+			name:    "unknown anchor",
+			input:   `{"properties": {"x": {"$ref": "#nowhere"}}}`,
+			wantErr: "unknown anchor",
+		},
+		{
+			name:    "remote reference",
+			input:   `{"properties": {"x": {"$ref": "https://example.com/other.json"}}}`,
+			wantErr: "unknown schema resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			got, err := ConvertSchemaToForm(schema)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ConvertSchemaToForm() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConvertSchemaToForm() error = %v", err)
+			}
+			if !tt.check(got) {
+				t.Errorf("ConvertSchemaToForm() check failed")
+			}
+		})
+	}
+}
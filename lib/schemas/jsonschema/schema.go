@@ -1,7 +1,10 @@
 package jsonschema
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"sort"
 )
 
 // Parse unmarshals a JSON Schema string into a Schema struct
@@ -26,6 +29,7 @@ type Schema struct {
 	Vocabularies  map[string]bool    `json:"$vocabularies,omitempty"`
 	Comment       string             `json:"$comment,omitempty"`
 	Defs          map[string]*Schema `json:"$defs,omitempty"`
+	Definitions   map[string]*Schema `json:"definitions,omitempty"` // Pre 2019-09 name for $defs
 
 	// Applicator vocabulary
 	AllOf                []*Schema          `json:"allOf,omitempty"`
@@ -88,6 +92,153 @@ type Schema struct {
 	Default     any    `json:"default,omitempty"`
 	Deprecated  *bool  `json:"deprecated,omitempty"`
 	ReadOnly    *bool  `json:"readOnly,omitempty"`
+
+	// Ordering extensions
+	XOrder  *int     `json:"x-order,omitempty"`  // Position of this schema among its sibling properties
+	UIOrder []string `json:"ui:order,omitempty"` // Explicit property order, "*" stands for all unlisted properties
+
+	// PropertyOrder holds the keys of Properties in the order they appear in the source document
+	PropertyOrder []string `json:"-"`
+}
+
+// UnmarshalJSON decodes a schema while recording the document order of its properties
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type schemaAlias Schema
+	var alias schemaAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*s = Schema(alias)
+
+	var raw struct {
+		Properties json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw.Properties) > 0 && !bytes.Equal(raw.Properties, []byte("null")) {
+		order, err := objectKeys(raw.Properties)
+		if err != nil {
+			return err
+		}
+		s.PropertyOrder = order
+	}
+
+	return nil
+}
+
+// objectKeys returns the keys of a JSON object in document order
+func objectKeys(data []byte) ([]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object key %v", token)
+		}
+		keys = append(keys, key)
+
+		// Skip the value
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+// OrderedPropertyNames returns the names of the schema's properties in display order.
+// Properties follow their document order (alphabetical for schemas built in code),
+// are then sorted by x-order, and finally arranged according to ui:order if present.
+func (s *Schema) OrderedPropertyNames() []string {
+	names := s.documentOrder()
+
+	// Properties with x-order come first, ascending; the rest keep their relative order
+	sort.SliceStable(names, func(i, j int) bool {
+		a, b := s.Properties[names[i]], s.Properties[names[j]]
+		if a == nil || a.XOrder == nil {
+			return false
+		}
+		if b == nil || b.XOrder == nil {
+			return true
+		}
+		return *a.XOrder < *b.XOrder
+	})
+
+	if len(s.UIOrder) == 0 {
+		return names
+	}
+
+	listed := make(map[string]bool, len(s.UIOrder))
+	for _, name := range s.UIOrder {
+		listed[name] = true
+	}
+	var unlisted []string
+	for _, name := range names {
+		if !listed[name] {
+			unlisted = append(unlisted, name)
+		}
+	}
+
+	ordered := make([]string, 0, len(names))
+	wildcard := false
+	for _, name := range s.UIOrder {
+		if name == "*" {
+			if !wildcard {
+				ordered = append(ordered, unlisted...)
+				wildcard = true
+			}
+			continue
+		}
+		if _, ok := s.Properties[name]; ok && !contains(ordered, name) {
+			ordered = append(ordered, name)
+		}
+	}
+	if !wildcard {
+		ordered = append(ordered, unlisted...)
+	}
+
+	return ordered
+}
+
+// documentOrder returns the names of the schema's properties in document order,
+// followed by any properties missing from PropertyOrder in alphabetical order
+func (s *Schema) documentOrder() []string {
+	names := make([]string, 0, len(s.Properties))
+	seen := make(map[string]bool, len(s.Properties))
+	for _, name := range s.PropertyOrder {
+		if _, ok := s.Properties[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+	var rest []string
+	for name := range s.Properties {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// contains reports whether names contains name
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
 }
 
 // GetType returns the type as a string or slice of strings
@@ -2,6 +2,7 @@ package jsonschema
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -142,3 +143,67 @@ func TestSchema_GetType(t *testing.T) {
 		})
 	}
 }
+
+func TestSchema_OrderedPropertyNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "document order",
+			input: `{"properties": {"zeta": {}, "alpha": {}, "mid": {}}}`,
+			want:  []string{"zeta", "alpha", "mid"},
+		},
+		{
+			name:  "x-order",
+			input: `{"properties": {"a": {}, "b": {"x-order": 2}, "c": {"x-order": 1}}}`,
+			want:  []string{"c", "b", "a"},
+		},
+		{
+			name:  "ui:order without wildcard",
+			input: `{"ui:order": ["c", "a"], "properties": {"a": {}, "b": {}, "c": {}}}`,
+			want:  []string{"c", "a", "b"},
+		},
+		{
+			name:  "ui:order with wildcard",
+			input: `{"ui:order": ["c", "*", "a"], "properties": {"a": {}, "b": {}, "c": {}, "d": {}}}`,
+			want:  []string{"c", "b", "d", "a"},
+		},
+		{
+			name:  "ui:order ignores unknown properties",
+			input: `{"ui:order": ["missing", "b"], "properties": {"a": {}, "b": {}}}`,
+			want:  []string{"b", "a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got := schema.OrderedPropertyNames()
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Schema.OrderedPropertyNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchema_OrderedPropertyNames_WithoutDocumentOrder(t *testing.T) {
+	schema := &Schema{
+		Properties: map[string]*Schema{
+			"b": {},
+			"c": {},
+			"a": {},
+		},
+	}
+
+	for i := 0; i < 10; i++ {
+		got := schema.OrderedPropertyNames()
+		if strings.Join(got, ",") != "a,b,c" {
+			t.Fatalf("Schema.OrderedPropertyNames() = %v, want [a b c]", got)
+		}
+	}
+}
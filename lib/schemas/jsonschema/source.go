@@ -0,0 +1,36 @@
+package jsonschema
+
+import (
+	"fmt"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+func init() {
+	lib.RegisterSource("jsonschema", lib.SchemaSourceFunc(convertJSON), "application/schema+json")
+	lib.RegisterSource("yaml", lib.SchemaSourceFunc(convertYAML), "application/schema+yaml", "application/yaml")
+}
+
+// convertJSON is the schema source of JSON Schemas written in JSON
+func convertJSON(data []byte, name string) (*lib.Form, error) {
+	if name != "" {
+		return nil, fmt.Errorf("a JSON Schema holds a single form, got name %q", name)
+	}
+	schema, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertSchemaToForm(schema)
+}
+
+// convertYAML is the schema source of JSON Schemas written in YAML, or in JSON with comments
+func convertYAML(data []byte, name string) (*lib.Form, error) {
+	if name != "" {
+		return nil, fmt.Errorf("a JSON Schema holds a single form, got name %q", name)
+	}
+	schema, err := ParseYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertSchemaToForm(schema)
+}
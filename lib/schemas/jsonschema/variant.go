@@ -0,0 +1,171 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+// variantAlternatives returns the oneOf (or else anyOf) subschemas of schema,
+// leaving out alternatives that only allow null
+func variantAlternatives(schema *Schema) []*Schema {
+	alternatives := schema.OneOf
+	if len(alternatives) == 0 {
+		alternatives = schema.AnyOf
+	}
+
+	result := make([]*Schema, 0, len(alternatives))
+	for _, alternative := range alternatives {
+		if typeStr, _, _ := alternative.GetType(); typeStr == "null" {
+			continue
+		}
+		result = append(result, alternative)
+	}
+	return result
+}
+
+// withoutAlternatives returns a copy of schema without oneOf/anyOf
+func withoutAlternatives(schema *Schema) *Schema {
+	base := *schema
+	base.OneOf = nil
+	base.AnyOf = nil
+	return &base
+}
+
+// buildVariants turns field into a variant field with one variant per alternative.
+// Keywords next to oneOf/anyOf apply to every alternative and are merged into each.
+// Alternatives that all define a const property with distinct values are told apart
+// by that discriminator property, which is then submitted by the variant selector.
+func (c *converter) buildVariants(field *lib.Field, schema *Schema, alternatives []*Schema) error {
+	base := withoutAlternatives(schema)
+	base.Title = ""
+	base.Description = ""
+	base.Default = nil
+
+	// Alternatives are resolved one at a time, so those sharing a referenced base are not
+	// taken for a cycle
+	schemas := make([]*Schema, 0, len(alternatives))
+	for _, alternative := range alternatives {
+		resolved, release, err := c.effectiveSchema(alternative)
+		if err != nil {
+			return err
+		}
+		release()
+		schemas = append(schemas, mergeSchemas(resolved, base))
+	}
+
+	field.Type = lib.FieldTypeVariant
+	field.Discriminator = discriminatorOf(schemas)
+
+	var valueLabels []string
+	if field.Discriminator != "" {
+		values := make([]any, len(schemas))
+		for i, variantSchema := range schemas {
+			values[i] = constValue(variantSchema.Properties[field.Discriminator])
+		}
+		valueLabels = lib.ValueLabels(values, c.opts.Label)
+	}
+
+	for i, variantSchema := range schemas {
+		variant, err := c.buildVariant(field, i, alternatives[i], variantSchema, valueLabels)
+		if err != nil {
+			return err
+		}
+		field.Variants = append(field.Variants, *variant)
+	}
+
+	return nil
+}
+
+// buildVariant converts the i-th alternative of a variant field, merged with the keywords
+// next to it into variantSchema. Variants without a title are labeled by the labels of the
+// discriminator values, if any. The schemas the alternative references count as being
+// converted until its fields are, so recursion through them is detected.
+func (c *converter) buildVariant(field *lib.Field, i int, alternative, variantSchema *Schema, valueLabels []string) (*lib.Variant, error) {
+	_, release, err := c.effectiveSchema(alternative)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	variant := lib.Variant{Label: variantSchema.Title, Value: i}
+	if field.Discriminator != "" {
+		variant.Value = constValue(variantSchema.Properties[field.Discriminator])
+	}
+	if variant.Label == "" && field.Discriminator != "" {
+		variant.Label = valueLabels[i]
+	}
+	if variant.Label == "" {
+		variant.Label = fmt.Sprintf("Option %d", i+1)
+	}
+
+	fieldType, err := c.determineFieldType(variantSchema)
+	if err != nil {
+		return nil, err
+	}
+	if fieldType == lib.FieldTypeObject && len(variantSchema.Enum) == 0 && variantSchema.Const == nil {
+		fields, err := c.convertPropertiesToFields(variantSchema)
+		if err != nil {
+			return nil, fmt.Errorf("error converting variant %q: %w", variant.Label, err)
+		}
+		for _, nested := range fields {
+			if nested.Name != field.Discriminator || field.Discriminator == "" {
+				variant.Fields = append(variant.Fields, nested)
+			}
+		}
+	} else {
+		valueField, err := c.convertSchemaToField("value", variantSchema)
+		if err != nil {
+			return nil, fmt.Errorf("error converting variant %q: %w", variant.Label, err)
+		}
+		valueField.Label = variant.Label
+		variant.Fields = []lib.Field{*valueField}
+		variant.Scalar = true
+	}
+
+	return &variant, nil
+}
+
+// discriminatorOf returns the first property that every schema fixes to a distinct
+// constant value, or "" if there is none
+func discriminatorOf(schemas []*Schema) string {
+	for _, name := range schemas[0].OrderedPropertyNames() {
+		seen := make([]any, 0, len(schemas))
+		for _, schema := range schemas {
+			value := constValue(schema.Properties[name])
+			if value == nil || containsValue(seen, value) {
+				break
+			}
+			seen = append(seen, value)
+		}
+		if len(seen) == len(schemas) {
+			return name
+		}
+	}
+	return ""
+}
+
+// constValue returns the single value a schema allows through const or a one-value enum, or nil
+func constValue(schema *Schema) any {
+	if schema == nil {
+		return nil
+	}
+	if schema.Const != nil {
+		return schema.Const
+	}
+	if len(schema.Enum) == 1 {
+		return schema.Enum[0]
+	}
+	return nil
+}
+
+// containsValue reports whether values contains value
+func containsValue(values []any, value any) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
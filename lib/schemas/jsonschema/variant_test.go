@@ -0,0 +1,222 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+func TestConvertSchemaToForm_Variants(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		check func(*testing.T, *lib.Field)
+	}{
+		{
+			name: "oneOf with const discriminator",
+			input: `{
+				"properties": {
+					"payment": {
+						"title": "Payment method",
+						"oneOf": [
+							{"$ref": "#/$defs/card"},
+							{
+								"properties": {
+									"method": {"const": "bank"},
+									"iban": {"type": "string"}
+								},
+								"required": ["iban"]
+							},
+							{"title": "Invoice", "properties": {"method": {"enum": ["invoice"]}}}
+						]
+					}
+				},
+				"$defs": {
+					"card": {
+						"title": "Card",
+						"type": "object",
+						"properties": {
+							"method": {"type": "string", "const": "card"},
+							"number": {"type": "string", "pattern": "[0-9]{16}"}
+						}
+					}
+				}
+			}`,
+			check: func(t *testing.T, f *lib.Field) {
+				if f.Type != lib.FieldTypeVariant || f.Label != "Payment method" {
+					t.Fatalf("field = %s/%q, want variant/Payment method", f.Type, f.Label)
+				}
+				if f.Discriminator != "method" {
+					t.Errorf("Discriminator = %q, want method", f.Discriminator)
+				}
+				if len(f.Variants) != 3 {
+					t.Fatalf("got %d variants, want 3", len(f.Variants))
+				}
+				wantLabels := []string{"Card", "Bank", "Invoice"}
+				wantValues := []any{"card", "bank", "invoice"}
+				for i, variant := range f.Variants {
+					if variant.Label != wantLabels[i] || variant.Value != wantValues[i] {
+						t.Errorf("variant %d = %q/%v, want %q/%v", i, variant.Label, variant.Value, wantLabels[i], wantValues[i])
+					}
+				}
+				if got := fieldNames(f.Variants[0].Fields); got != "number" {
+					t.Errorf("card fields = %s, want number", got)
+				}
+				iban := f.Variants[1].Fields[0]
+				if iban.Validation == nil || !iban.Validation.Required {
+					t.Errorf("bank iban should be required")
+				}
+				if len(f.Variants[2].Fields) != 0 {
+					t.Errorf("invoice has %d fields, want 0", len(f.Variants[2].Fields))
+				}
+			},
+		},
+		{
+			name: "anyOf without discriminator",
+			input: `{
+				"properties": {
+					"contact": {
+						"anyOf": [
+							{"properties": {"email": {"type": "string", "format": "email"}}},
+							{"title": "Phone", "properties": {"phone": {"type": "string"}}}
+						]
+					}
+				}
+			}`,
+			check: func(t *testing.T, f *lib.Field) {
+				if f.Type != lib.FieldTypeVariant || f.Discriminator != "" {
+					t.Fatalf("field = %s with discriminator %q, want variant without discriminator", f.Type, f.Discriminator)
+				}
+				if f.Variants[0].Label != "Option 1" || f.Variants[1].Label != "Phone" {
+					t.Errorf("labels = %q, %q, want Option 1, Phone", f.Variants[0].Label, f.Variants[1].Label)
+				}
+				if f.Variants[0].Value != 0 || f.Variants[1].Value != 1 {
+					t.Errorf("values = %v, %v, want 0, 1", f.Variants[0].Value, f.Variants[1].Value)
+				}
+			},
+		},
+		{
+			name: "shared properties are merged into every variant",
+			input: `{
+				"properties": {
+					"shape": {
+						"type": "object",
+						"properties": {"color": {"type": "string"}},
+						"oneOf": [
+							{"properties": {"kind": {"const": "circle"}, "radius": {"type": "number"}}},
+							{"properties": {"kind": {"const": "square"}, "side": {"type": "number"}}}
+						]
+					}
+				}
+			}`,
+			check: func(t *testing.T, f *lib.Field) {
+				if got := fieldNames(f.Variants[0].Fields); got != "radius,color" {
+					t.Errorf("circle fields = %s, want radius,color", got)
+				}
+				if got := fieldNames(f.Variants[1].Fields); got != "side,color" {
+					t.Errorf("square fields = %s, want side,color", got)
+				}
+			},
+		},
+		{
+			name: "scalar alternatives",
+			input: `{
+				"properties": {
+					"limit": {
+						"oneOf": [
+							{"title": "Unlimited", "const": "unlimited"},
+							{"title": "Count", "type": "integer", "minimum": 1}
+						]
+					}
+				}
+			}`,
+			check: func(t *testing.T, f *lib.Field) {
+				count := f.Variants[1]
+				if !count.Scalar || len(count.Fields) != 1 {
+					t.Fatalf("count variant = %+v, want a scalar variant with one field", count)
+				}
+				if value := count.Fields[0]; value.Name != "value" || value.DataType != lib.DataTypeInteger || *value.Validation.Min != 1 {
+					t.Errorf("count field = %+v, want integer value field with min 1", value)
+				}
+				if unlimited := f.Variants[0].Fields[0]; unlimited.Type != lib.FieldTypeHidden || unlimited.Value != "unlimited" {
+					t.Errorf("unlimited field = %s/%v, want hidden/unlimited", unlimited.Type, unlimited.Value)
+				}
+			},
+		},
+		{
+			name: "alternatives sharing a referenced base",
+			input: `{
+				"properties": {
+					"pay": {"oneOf": [
+						{"allOf": [{"$ref": "#/$defs/base"}], "properties": {"kind": {"const": "card"}, "number": {"type": "string"}}},
+						{"allOf": [{"$ref": "#/$defs/base"}], "properties": {"kind": {"const": "bank"}, "iban": {"type": "string"}}}
+					]}
+				},
+				"$defs": {
+					"base": {"type": "object", "properties": {"amount": {"type": "number"}}, "required": ["amount"]}
+				}
+			}`,
+			check: func(t *testing.T, f *lib.Field) {
+				if f.Discriminator != "kind" || len(f.Variants) != 2 {
+					t.Fatalf("pay = %d variants discriminated by %q, want 2 by kind", len(f.Variants), f.Discriminator)
+				}
+				if got := fieldNames(f.Variants[0].Fields); got != "number,amount" {
+					t.Errorf("card fields = %s, want number,amount", got)
+				}
+				if got := fieldNames(f.Variants[1].Fields); got != "iban,amount" {
+					t.Errorf("bank fields = %s, want iban,amount", got)
+				}
+			},
+		},
+		{
+			name: "nullable alternative is not a variant",
+			input: `{
+				"properties": {
+					"nickname": {"oneOf": [{"type": "null"}, {"type": "string", "maxLength": 20}]}
+				}
+			}`,
+			check: func(t *testing.T, f *lib.Field) {
+				if f.Type != lib.FieldTypeText || f.Validation == nil || *f.Validation.MaxLength != 20 {
+					t.Errorf("field = %s with validation %+v, want text with maxLength 20", f.Type, f.Validation)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			got, err := ConvertSchemaToForm(schema)
+			if err != nil {
+				t.Fatalf("ConvertSchemaToForm() error = %v", err)
+			}
+			if err := got.Validate(); err != nil {
+				t.Fatalf("Form.Validate() error = %v", err)
+			}
+			tt.check(t, &got.Fields[0])
+		})
+	}
+}
+
+func TestConvertSchemaToForm_RecursiveVariant(t *testing.T) {
+	schema, err := Parse([]byte(`{
+		"properties": {"root": {"$ref": "#/$defs/node"}},
+		"$defs": {
+			"node": {"oneOf": [
+				{"properties": {"kind": {"const": "leaf"}}},
+				{"properties": {"kind": {"const": "branch"}, "child": {"$ref": "#/$defs/node"}}}
+			]}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ConvertSchemaToForm(schema); err == nil || !strings.Contains(err.Error(), "circular $ref") {
+		t.Errorf("ConvertSchemaToForm() error = %v, want circular $ref", err)
+	}
+}
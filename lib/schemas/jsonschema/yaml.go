@@ -0,0 +1,414 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLError is an error in a YAML schema, located at the line and column of the offending
+// value. Column is 0 if only the line is known.
+type YAMLError struct {
+	Line   int
+	Column int
+	Msg    string
+	Err    error // The underlying error, if any
+}
+
+// Error implements the error interface
+func (e *YAMLError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+	}
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// Unwrap returns the underlying error
+func (e *YAMLError) Unwrap() error {
+	return e.Err
+}
+
+// yamlLineError matches the syntax errors of the YAML parser, which only carry a line number
+var yamlLineError = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// yamlUnknownAnchor matches the error of the YAML parser for an alias without an anchor
+var yamlUnknownAnchor = regexp.MustCompile(`^unknown anchor '(.*)' referenced$`)
+
+// ParseYAML unmarshals a JSON Schema written in YAML into a Schema struct. Properties keep
+// their order in the YAML document. JSON schemas are accepted too, including JSONC and JSON5
+// documents with comments, trailing commas, unquoted keys, single-quoted strings and
+// hexadecimal numbers. Errors are YAMLErrors pointing to the offending value.
+func ParseYAML(data []byte) (*Schema, error) {
+	root, converted, err := yamlToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := Parse(converted)
+	if err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			if node := locateTypeError(root, typeErr); node != nil {
+				return nil, &YAMLError{
+					Line:   node.Line,
+					Column: node.Column,
+					Msg:    fmt.Sprintf("invalid %s: cannot use %s as %s", typeErr.Field, typeErr.Value, typeErr.Type),
+					Err:    err,
+				}
+			}
+		}
+		return nil, err
+	}
+	return schema, nil
+}
+
+// YAMLToJSON converts a document written in YAML, or in JSON with comments, to JSON the way
+// ParseYAML reads schemas, so other documents embedding JSON Schemas can be written in YAML
+// too. Mapping keys keep their order. Errors are YAMLErrors pointing to the offending value.
+func YAMLToJSON(data []byte) ([]byte, error) {
+	_, converted, err := yamlToJSON(data)
+	return converted, err
+}
+
+// yamlToJSON converts a YAML or JSON5 document to JSON, returning the node of its value too
+func yamlToJSON(data []byte) (*yaml.Node, []byte, error) {
+	var root *yaml.Node
+	var err error
+	if isJSONDocument(data) {
+		root, err = parseJSON5(data)
+	} else {
+		root, err = parseYAMLNode(data)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeYAMLAsJSON(&buf, root); err != nil {
+		return nil, nil, err
+	}
+	return root, buf.Bytes(), nil
+}
+
+// parseYAMLNode parses a YAML document into the node of its single value. Syntax errors
+// are YAMLErrors; the YAML parser leaves out the line of errors on the first line, and
+// reports errors in the characters of the document without a position.
+func parseYAMLNode(data []byte) (*yaml.Node, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		if match := yamlLineError.FindStringSubmatch(err.Error()); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			return nil, &YAMLError{Line: line, Msg: match[2], Err: err}
+		}
+
+		msg := strings.TrimPrefix(err.Error(), "yaml: ")
+		yamlErr := &YAMLError{Line: 1, Msg: msg, Err: err}
+		if offset := invalidCharacter(data); offset >= 0 {
+			yamlErr.Line, yamlErr.Column = position(data, offset)
+		} else if match := yamlUnknownAnchor.FindStringSubmatch(msg); match != nil {
+			if offset := bytes.Index(data, []byte("*"+match[1])); offset >= 0 {
+				yamlErr.Line, yamlErr.Column = position(data, offset)
+			}
+		}
+		return nil, yamlErr
+	}
+	if len(document.Content) == 0 {
+		return nil, fmt.Errorf("empty document")
+	}
+	return document.Content[0], nil
+}
+
+// invalidCharacter returns the offset of the first character YAML does not allow in a
+// document, such as a control character or invalid UTF-8, or -1 if there is none
+func invalidCharacter(data []byte) int {
+	for offset := 0; offset < len(data); {
+		r, size := utf8.DecodeRune(data[offset:])
+		allowed := r == '\t' || r == '\n' || r == '\r' || (r >= 0x20 && r <= 0x7E) || r == 0x85 ||
+			(r >= 0xA0 && r <= 0xD7FF) || (r >= 0xE000 && r <= 0xFFFD) || r >= 0x10000
+		if !allowed || (r == utf8.RuneError && size == 1) {
+			return offset
+		}
+		offset += size
+	}
+	return -1
+}
+
+// position returns the 1-based line and column of an offset in data
+func position(data []byte, offset int) (line, column int) {
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = utf8.RuneCount(before[bytes.LastIndexByte(before, '\n')+1:]) + 1
+	return line, column
+}
+
+// maxExpandedNodes is the number of nodes that aliases and merge keys may add to a document
+// when expanded. Aliases may refer to values holding further aliases, so a short document
+// could otherwise expand exponentially.
+const maxExpandedNodes = 100000
+
+// yamlWriter writes YAML nodes as JSON. It counts the nodes it visits, and rejects
+// documents whose aliases expand to more than maxExpandedNodes nodes beyond their own.
+type yamlWriter struct {
+	buf   *bytes.Buffer
+	nodes int
+	limit int
+}
+
+// newYAMLWriter returns a writer to buf for the document rooted at node
+func newYAMLWriter(buf *bytes.Buffer, node *yaml.Node) *yamlWriter {
+	return &yamlWriter{buf: buf, limit: countNodes(node) + maxExpandedNodes}
+}
+
+// writeYAMLAsJSON writes a YAML node as JSON, keeping the order of mapping keys
+func writeYAMLAsJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	return newYAMLWriter(buf, node).write(node)
+}
+
+// countNodes returns the number of nodes in a document, without following aliases
+func countNodes(node *yaml.Node) int {
+	count := 1
+	for _, child := range node.Content {
+		count += countNodes(child)
+	}
+	return count
+}
+
+// count records a node being visited, and fails once aliases have expanded the document
+// past its limit
+func (w *yamlWriter) count(node *yaml.Node) error {
+	w.nodes++
+	if w.nodes > w.limit {
+		return &YAMLError{Line: node.Line, Column: node.Column, Msg: "document contains excessive aliasing"}
+	}
+	return nil
+}
+
+// write writes a node as JSON
+func (w *yamlWriter) write(node *yaml.Node) error {
+	if err := w.count(node); err != nil {
+		return err
+	}
+
+	buf := w.buf
+	switch node.Kind {
+	case yaml.AliasNode:
+		return w.write(node.Alias)
+
+	case yaml.MappingNode:
+		entries, err := w.mappingEntries(node)
+		if err != nil {
+			return err
+		}
+		buf.WriteByte('{')
+		for i, entry := range entries {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(entry.key)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := w.write(entry.value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := w.write(item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+
+	case yaml.ScalarNode:
+		value, err := scalarValue(node)
+		if err != nil {
+			return &YAMLError{Line: node.Line, Column: node.Column, Msg: err.Error(), Err: err}
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return &YAMLError{Line: node.Line, Column: node.Column, Msg: err.Error(), Err: err}
+		}
+		buf.Write(data)
+		return nil
+	}
+
+	return &YAMLError{Line: node.Line, Column: node.Column, Msg: "unsupported YAML node"}
+}
+
+// scalarValue returns the value of a scalar node. Timestamps and binary values are kept as
+// the strings they are written as, the way JSON Schema formats expect them.
+func scalarValue(node *yaml.Node) (any, error) {
+	switch node.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var value bool
+		err := node.Decode(&value)
+		return value, err
+	case "!!int":
+		var value int64
+		if err := node.Decode(&value); err == nil {
+			return value, nil
+		}
+		var unsigned uint64
+		err := node.Decode(&unsigned)
+		return unsigned, err
+	case "!!float":
+		var value float64
+		if err := node.Decode(&value); err != nil {
+			return nil, err
+		}
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return nil, fmt.Errorf("%s cannot be represented in JSON", node.Value)
+		}
+		return value, nil
+	}
+	return node.Value, nil
+}
+
+// yamlEntry is a single key and value of a YAML mapping
+type yamlEntry struct {
+	key   string
+	value *yaml.Node
+}
+
+// mappingEntries returns the entries of a mapping node in document order, with the entries
+// of mappings merged in with "<<" in place of the merge key. Keys set explicitly take
+// precedence over merged keys, and earlier merged keys over later ones.
+func (w *yamlWriter) mappingEntries(node *yaml.Node) ([]yamlEntry, error) {
+	var entries []yamlEntry
+	index := make(map[string]int)
+	explicit := make(map[string]bool)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if err := w.count(key); err != nil {
+			return nil, err
+		}
+
+		if key.ShortTag() == "!!merge" {
+			merged, err := w.mergedEntries(value)
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range merged {
+				if _, ok := index[entry.key]; !ok {
+					index[entry.key] = len(entries)
+					entries = append(entries, entry)
+				}
+			}
+			continue
+		}
+
+		if key.Kind != yaml.ScalarNode {
+			return nil, &YAMLError{Line: key.Line, Column: key.Column, Msg: "mapping keys must be scalars"}
+		}
+		if explicit[key.Value] {
+			return nil, &YAMLError{Line: key.Line, Column: key.Column, Msg: fmt.Sprintf("duplicate key %q", key.Value)}
+		}
+		explicit[key.Value] = true
+		if i, ok := index[key.Value]; ok {
+			entries[i].value = value
+			continue
+		}
+		index[key.Value] = len(entries)
+		entries = append(entries, yamlEntry{key: key.Value, value: value})
+	}
+
+	return entries, nil
+}
+
+// mergedEntries returns the entries merged in by the value of a "<<" key: a mapping or a
+// sequence of mappings
+func (w *yamlWriter) mergedEntries(node *yaml.Node) ([]yamlEntry, error) {
+	if err := w.count(node); err != nil {
+		return nil, err
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		return w.mappingEntries(node)
+	case yaml.SequenceNode:
+		var entries []yamlEntry
+		seen := make(map[string]bool)
+		for _, item := range node.Content {
+			merged, err := w.mergedEntries(item)
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range merged {
+				if !seen[entry.key] {
+					seen[entry.key] = true
+					entries = append(entries, entry)
+				}
+			}
+		}
+		return entries, nil
+	}
+	return nil, &YAMLError{Line: node.Line, Column: node.Column, Msg: "merge value must be a mapping or a list of mappings"}
+}
+
+// locateTypeError finds the value a type error was raised for: the value of the error's
+// field in the innermost mapping that fails to unmarshal with the same error on its own
+func locateTypeError(node *yaml.Node, typeErr *json.UnmarshalTypeError) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		return nil // Aliased values are located at their anchor
+	}
+	for _, child := range node.Content {
+		if found := locateTypeError(child, typeErr); found != nil {
+			return found
+		}
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	entries, err := newYAMLWriter(nil, node).mappingEntries(node)
+	if err != nil {
+		return nil
+	}
+	for _, entry := range entries {
+		if entry.key != typeErr.Field {
+			continue
+		}
+		var buf bytes.Buffer
+		if writeYAMLAsJSON(&buf, node) != nil {
+			return nil
+		}
+		var schema Schema
+		var nested *json.UnmarshalTypeError
+		if err := json.Unmarshal(buf.Bytes(), &schema); errors.As(err, &nested) &&
+			nested.Field == typeErr.Field && nested.Value == typeErr.Value && nested.Type == typeErr.Type {
+			return entry.value
+		}
+	}
+	return nil
+}
+
+// isJSONDocument reports whether data is written in JSON style, starting with an object or
+// array after any white space and comments
+func isJSONDocument(data []byte) bool {
+	p := newJSON5Parser(data)
+	if p.skipSpace() != nil {
+		return false
+	}
+	return p.at('{') || p.at('[')
+}
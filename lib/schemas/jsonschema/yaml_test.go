@@ -0,0 +1,352 @@
+package jsonschema
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		check func(*Schema) bool
+	}{
+		{
+			name: "block style",
+			input: `
+type: object
+title: Signup
+required: [email]
+properties:
+  email:
+    type: string
+    format: email
+  age:
+    type: integer
+    minimum: 18
+`,
+			check: func(s *Schema) bool {
+				return s.Title == "Signup" && s.Properties["email"].Format == "email" &&
+					*s.Properties["age"].Minimum == 18 && reflect.DeepEqual(s.Required, []string{"email"})
+			},
+		},
+		{
+			name: "key order follows the document",
+			input: `
+properties:
+  zip: {type: string}
+  street: {type: string}
+  city: {type: string}
+`,
+			check: func(s *Schema) bool {
+				return reflect.DeepEqual(s.OrderedPropertyNames(), []string{"zip", "street", "city"})
+			},
+		},
+		{
+			name: "timestamps stay strings",
+			input: `
+type: string
+format: date
+default: 2024-01-31
+`,
+			check: func(s *Schema) bool {
+				return s.Default == "2024-01-31"
+			},
+		},
+		{
+			name: "anchors and merge keys",
+			input: `
+$defs:
+  text: &text
+    type: string
+    maxLength: 50
+properties:
+  first: *text
+  last:
+    <<: *text
+    maxLength: 20
+`,
+			check: func(s *Schema) bool {
+				return *s.Properties["first"].MaxLength == 50 && *s.Properties["last"].MaxLength == 20 &&
+					string(s.Properties["last"].Type) == `"string"`
+			},
+		},
+		{
+			name:  "json",
+			input: `{"type": "object", "properties": {"b": {"type": "string"}, "a": {"type": "number"}}}`,
+			check: func(s *Schema) bool {
+				return reflect.DeepEqual(s.OrderedPropertyNames(), []string{"b", "a"})
+			},
+		},
+		{
+			name:  "json escapes and json5 single quotes",
+			input: "// A comment before the document\n{\"pattern\": \"^a\\/b$\", title: 'it\\'s'}",
+			check: func(s *Schema) bool {
+				return s.Pattern == "^a/b$" && s.Title == "it's"
+			},
+		},
+		{
+			name: "jsonc and json5",
+			input: `{
+	// The user's name
+	title: 'Name', /* a label */
+	"type": "string",
+	"pattern": "^https?://", // not a comment inside strings
+	"maxLength": 0x20,
+}`,
+			check: func(s *Schema) bool {
+				return s.Title == "Name" && s.Pattern == "^https?://" && *s.MaxLength == 32
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseYAML([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("ParseYAML() error = %v", err)
+			}
+			if !tt.check(got) {
+				t.Errorf("ParseYAML() = %+v, check failed", got)
+			}
+		})
+	}
+}
+
+func TestParseYAML_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantErr    string
+		wantLine   int
+		wantColumn int
+	}{
+		{
+			name: "syntax error",
+			input: `type: object
+properties:
+  name: type: string
+`,
+			wantErr:  "line 3: mapping values are not allowed in this context",
+			wantLine: 3,
+		},
+		{
+			name: "wrong type of keyword",
+			input: `type: object
+properties:
+  name:
+    type: string
+    minLength: many
+`,
+			wantErr:    "line 5, column 16: invalid minLength: cannot use string as int",
+			wantLine:   5,
+			wantColumn: 16,
+		},
+		{
+			name:       "wrong type in json",
+			input:      "{\n  \"required\": \"name\"\n}",
+			wantErr:    "line 2, column 15: invalid required: cannot use string as []string",
+			wantLine:   2,
+			wantColumn: 15,
+		},
+		{
+			name: "duplicate key",
+			input: `title: A
+title: B
+`,
+			wantErr:    `line 2, column 1: duplicate key "title"`,
+			wantLine:   2,
+			wantColumn: 1,
+		},
+		{
+			name:       "unterminated string in json",
+			input:      `{"title": "abc`,
+			wantErr:    "line 1, column 11: unterminated string",
+			wantLine:   1,
+			wantColumn: 11,
+		},
+		{
+			name:       "missing comma in json",
+			input:      "{\n  \"a\": 1\n  \"b\": 2\n}",
+			wantErr:    `line 3, column 3: expected ',' or '}' in object, found character '"'`,
+			wantLine:   3,
+			wantColumn: 3,
+		},
+		{
+			name:       "invalid escape in json",
+			input:      `{"title": "a\1"}`,
+			wantErr:    `line 1, column 13: invalid escape sequence \1`,
+			wantLine:   1,
+			wantColumn: 13,
+		},
+		{
+			name:       "unterminated comment in jsonc",
+			input:      "{\n  /* title",
+			wantErr:    "line 2, column 3: unterminated comment",
+			wantLine:   2,
+			wantColumn: 3,
+		},
+		{
+			name:       "infinity in json5",
+			input:      `{maximum: -Infinity}`,
+			wantErr:    "line 1, column 11: -Infinity cannot be represented in JSON",
+			wantLine:   1,
+			wantColumn: 11,
+		},
+		{
+			name:       "number out of range in json",
+			input:      `[1e400]`,
+			wantErr:    "line 1, column 2: invalid number 1e400",
+			wantLine:   1,
+			wantColumn: 2,
+		},
+		{
+			name:     "syntax error on the first line",
+			input:    `title: "a\qb"`,
+			wantErr:  "line 1: found unknown escape character",
+			wantLine: 1,
+		},
+		{
+			name:       "control character",
+			input:      "title: a\nformat: \"x\x01\"\n",
+			wantErr:    "line 2, column 11: control characters are not allowed",
+			wantLine:   2,
+			wantColumn: 11,
+		},
+		{
+			name:       "unknown anchor",
+			input:      "title: a\ndefault: *value\n",
+			wantErr:    "line 2, column 10: unknown anchor 'value' referenced",
+			wantLine:   2,
+			wantColumn: 10,
+		},
+		{
+			name:       "infinity",
+			input:      "maximum: .inf\n",
+			wantErr:    "line 1, column 10: .inf cannot be represented in JSON",
+			wantLine:   1,
+			wantColumn: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseYAML([]byte(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ParseYAML() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			var yamlErr *YAMLError
+			if !errors.As(err, &yamlErr) {
+				t.Fatalf("ParseYAML() error = %T, want *YAMLError", err)
+			}
+			if yamlErr.Line != tt.wantLine || yamlErr.Column != tt.wantColumn {
+				t.Errorf("ParseYAML() error at %d:%d, want %d:%d", yamlErr.Line, yamlErr.Column, tt.wantLine, tt.wantColumn)
+			}
+		})
+	}
+}
+
+func TestParseYAML_Aliasing(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name: "nested aliases",
+			input: `
+a: &a [x, x, x, x, x, x, x, x, x]
+b: &b [*a, *a, *a, *a, *a, *a, *a, *a, *a]
+c: &c [*b, *b, *b, *b, *b, *b, *b, *b, *b]
+d: &d [*c, *c, *c, *c, *c, *c, *c, *c, *c]
+e: &e [*d, *d, *d, *d, *d, *d, *d, *d, *d]
+f: &f [*e, *e, *e, *e, *e, *e, *e, *e, *e]
+g: &g [*f, *f, *f, *f, *f, *f, *f, *f, *f]
+h: &h [*g, *g, *g, *g, *g, *g, *g, *g, *g]
+i: &i [*h, *h, *h, *h, *h, *h, *h, *h, *h]
+`,
+		},
+		{
+			name: "nested merge keys",
+			input: `
+a: &a {x: 1}
+b: &b {<<: [*a, *a, *a, *a, *a, *a, *a, *a, *a]}
+c: &c {<<: [*b, *b, *b, *b, *b, *b, *b, *b, *b]}
+d: &d {<<: [*c, *c, *c, *c, *c, *c, *c, *c, *c]}
+e: &e {<<: [*d, *d, *d, *d, *d, *d, *d, *d, *d]}
+f: &f {<<: [*e, *e, *e, *e, *e, *e, *e, *e, *e]}
+g: &g {<<: [*f, *f, *f, *f, *f, *f, *f, *f, *f]}
+h: &h {<<: [*g, *g, *g, *g, *g, *g, *g, *g, *g]}
+i: &i {<<: [*h, *h, *h, *h, *h, *h, *h, *h, *h]}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseYAML([]byte(tt.input))
+			var yamlErr *YAMLError
+			if !errors.As(err, &yamlErr) || !strings.Contains(err.Error(), "excessive aliasing") {
+				t.Fatalf("ParseYAML() error = %v, want excessive aliasing", err)
+			}
+		})
+	}
+}
+
+func TestParseYAML_Empty(t *testing.T) {
+	if _, err := ParseYAML([]byte("  \n")); err == nil {
+		t.Error("ParseYAML() error = nil, want error for an empty document")
+	}
+}
+
+func TestParseJSON5(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "json",
+			input: `{"a": [1, -2.5, 1e3, true, null], "b": {}}`,
+			want:  `{"a":[1,-2.5,1000,true,null],"b":{}}`,
+		},
+		{
+			name:  "json escapes",
+			input: `{"pattern": "^a\/b\\$", "text": "tab\tquote\" \u00e9 \ud83d\ude00"}`,
+			want:  `{"pattern":"^a/b\\$","text":"tab\tquote\" é 😀"}`,
+		},
+		{
+			name:  "comments",
+			input: "{\n  // line\n  \"a\": \"//x\", /* block\n comment */ 'b': 'it\\'s' // end\n}",
+			want:  `{"a":"//x","b":"it's"}`,
+		},
+		{
+			name:  "json5 strings",
+			input: "{title: 'say \"hi\"', $id: \"a\\\nb\", _x: '\\x41\\v'}",
+			want:  `{"title":"say \"hi\"","$id":"ab","_x":"A\u000b"}`,
+		},
+		{
+			name:  "json5 numbers and trailing commas",
+			input: `[+1, -.5, 5., 0x1F, -0XFF, 18446744073709551615,]`,
+			want:  `[1,-0.5,5,31,-255,18446744073709551615]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := parseJSON5([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("parseJSON5() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := writeYAMLAsJSON(&buf, node); err != nil {
+				t.Fatalf("writeYAMLAsJSON() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("parseJSON5() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
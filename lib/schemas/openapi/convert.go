@@ -0,0 +1,334 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Olian04/form-from-schema/lib"
+	"github.com/Olian04/form-from-schema/lib/schemas/jsonschema"
+)
+
+// BodyFieldName is the name of the field holding a request body that is not an object
+const BodyFieldName = "body"
+
+// preferredMediaTypes are the request body media types forms are generated from, in order of preference
+var preferredMediaTypes = []string{"application/json", "application/x-www-form-urlencoded", "multipart/form-data"}
+
+// ConvertOperationToForm converts the operation with the given operationId to a Form
+// structure. Its path and query parameters become the first fields, followed by the
+// properties of its request body, or a single "body" field if the body is not an object.
+// The form submits to the operation's path, prefixed by its server URL, using the
+// operation's method. Path templates such as {id} are kept in the action, for targets to
+// fill in from the values of the path parameter fields, which are submitted like the
+// other fields. HTML forms can only be submitted with GET and POST; the HTML target
+// submits other methods with POST, naming the method in a lib.MethodParam parameter.
+func ConvertOperationToForm(doc *Document, operationID string) (*lib.Form, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("document cannot be nil")
+	}
+
+	path, method, item, operation, err := findOperation(doc, operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := operationSchema(doc, item, operation)
+	if err != nil {
+		return nil, fmt.Errorf("operation %q: %w", operationID, err)
+	}
+
+	form, err := jsonschema.ConvertSchemaToForm(root)
+	if err != nil {
+		return nil, fmt.Errorf("operation %q: %w", operationID, err)
+	}
+	form.Action = serverURL(operationServers(doc, item, operation)) + path
+	form.Method = method
+	return form, nil
+}
+
+// findOperation returns the operation with the given operationId along with its path,
+// upper-case method and path item
+func findOperation(doc *Document, operationID string) (string, string, *PathItem, *Operation, error) {
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var found []string
+	var path, method string
+	var item *PathItem
+	var operation *Operation
+	for _, p := range paths {
+		if doc.Paths[p] == nil {
+			continue
+		}
+		for m, op := range doc.Paths[p].Operations() {
+			if op.OperationID == operationID {
+				found = append(found, m+" "+p)
+				path, method, item, operation = p, m, doc.Paths[p], op
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return "", "", nil, nil, fmt.Errorf("operation %q not found", operationID)
+	case 1:
+		return path, method, item, operation, nil
+	}
+	sort.Strings(found)
+	return "", "", nil, nil, fmt.Errorf("operationId %q is not unique: %s", operationID, strings.Join(found, ", "))
+}
+
+// operationSchema builds the object schema the form of an operation is converted from
+func operationSchema(doc *Document, item *PathItem, operation *Operation) (*jsonschema.Schema, error) {
+	root := &jsonschema.Schema{
+		Type:        json.RawMessage(`"object"`),
+		Title:       operation.Summary,
+		Description: operation.Description,
+		Defs:        doc.Components.Schemas,
+		Properties:  make(map[string]*jsonschema.Schema),
+	}
+
+	parameters, err := operationParameters(doc, item, operation)
+	if err != nil {
+		return nil, err
+	}
+	for _, parameter := range parameters {
+		schema, err := parameterSchema(parameter)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", parameter.Name, err)
+		}
+		root.Properties[parameter.Name] = schema
+		root.PropertyOrder = append(root.PropertyOrder, parameter.Name)
+		if parameter.Required || parameter.In == "path" {
+			root.Required = append(root.Required, parameter.Name)
+		}
+	}
+
+	body, err := resolveRequestBody(doc, operation.RequestBody)
+	if err != nil {
+		return nil, err
+	}
+	schema := bodySchema(body)
+	if schema == nil {
+		return root, nil
+	}
+	if root.Description == "" {
+		root.Description = body.Description
+	}
+
+	// Object bodies contribute their properties, other bodies become a single field
+	if object := resolveSchema(doc, schema); isObjectSchema(object) {
+		for name := range object.Properties {
+			if _, ok := root.Properties[name]; ok {
+				return nil, fmt.Errorf("parameter %q conflicts with a request body property", name)
+			}
+		}
+		root.AllOf = []*jsonschema.Schema{schema}
+		return root, nil
+	}
+
+	if _, ok := root.Properties[BodyFieldName]; ok {
+		return nil, fmt.Errorf("parameter %q conflicts with the request body", BodyFieldName)
+	}
+	root.Properties[BodyFieldName] = schema
+	root.PropertyOrder = append(root.PropertyOrder, BodyFieldName)
+	if body.Required {
+		root.Required = append(root.Required, BodyFieldName)
+	}
+	return root, nil
+}
+
+// operationParameters returns the path and query parameters of an operation, including
+// those declared on its path item unless the operation overrides them
+func operationParameters(doc *Document, item *PathItem, operation *Operation) ([]*Parameter, error) {
+	var parameters []*Parameter
+	index := make(map[string]int)
+
+	for _, declared := range append(append([]*Parameter{}, item.Parameters...), operation.Parameters...) {
+		parameter, err := resolveParameter(doc, declared)
+		if err != nil {
+			return nil, err
+		}
+		if parameter.In != "path" && parameter.In != "query" {
+			continue
+		}
+		key := parameter.In + ":" + parameter.Name
+		if i, ok := index[key]; ok {
+			parameters[i] = parameter
+			continue
+		}
+		if i := parameterIndex(parameters, parameter.Name); i >= 0 {
+			return nil, fmt.Errorf("parameter %q is declared in both %s and %s", parameter.Name, parameters[i].In, parameter.In)
+		}
+		index[key] = len(parameters)
+		parameters = append(parameters, parameter)
+	}
+
+	return parameters, nil
+}
+
+// parameterIndex returns the index of the parameter with the given name, or -1
+func parameterIndex(parameters []*Parameter, name string) int {
+	for i, parameter := range parameters {
+		if parameter.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// parameterSchema returns the schema of a parameter, annotated with its description and deprecation
+func parameterSchema(parameter *Parameter) (*jsonschema.Schema, error) {
+	schema := parameter.Schema
+	if schema == nil {
+		if media := preferredMediaType(parameter.Content); media != nil {
+			schema = media.Schema
+		}
+	}
+	if schema == nil {
+		return nil, fmt.Errorf("missing schema")
+	}
+
+	annotated := &jsonschema.Schema{
+		Description: parameter.Description,
+		AllOf:       []*jsonschema.Schema{schema},
+	}
+	if parameter.Deprecated {
+		deprecated := true
+		annotated.Deprecated = &deprecated
+	}
+	return annotated, nil
+}
+
+// bodySchema returns the schema of the preferred media type of a request body, or nil
+func bodySchema(body *RequestBody) *jsonschema.Schema {
+	if body == nil {
+		return nil
+	}
+	if media := preferredMediaType(body.Content); media != nil {
+		return media.Schema
+	}
+	return nil
+}
+
+// preferredMediaType returns the content of the most preferred media type, falling back to
+// the first media type in alphabetical order
+func preferredMediaType(content map[string]*MediaType) *MediaType {
+	for _, mediaType := range preferredMediaTypes {
+		if media, ok := content[mediaType]; ok && media != nil {
+			return media
+		}
+	}
+
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType, media := range content {
+		if media != nil {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		return nil
+	}
+	sort.Strings(mediaTypes)
+	return content[mediaTypes[0]]
+}
+
+// resolveParameter follows a parameter's reference to components/parameters
+func resolveParameter(doc *Document, parameter *Parameter) (*Parameter, error) {
+	seen := make(map[string]bool)
+	for parameter != nil && parameter.Ref != "" {
+		ref := parameter.Ref
+		if seen[ref] {
+			return nil, fmt.Errorf("circular $ref %q", ref)
+		}
+		seen[ref] = true
+
+		name, ok := strings.CutPrefix(ref, "#/components/parameters/")
+		if !ok || doc.Components.Parameters[name] == nil {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+		parameter = doc.Components.Parameters[name]
+	}
+	if parameter == nil {
+		return nil, fmt.Errorf("parameter cannot be null")
+	}
+	return parameter, nil
+}
+
+// resolveRequestBody follows a request body's reference to components/requestBodies
+func resolveRequestBody(doc *Document, body *RequestBody) (*RequestBody, error) {
+	seen := make(map[string]bool)
+	for body != nil && body.Ref != "" {
+		ref := body.Ref
+		if seen[ref] {
+			return nil, fmt.Errorf("circular $ref %q", ref)
+		}
+		seen[ref] = true
+
+		name, ok := strings.CutPrefix(ref, "#/components/requestBodies/")
+		if !ok || doc.Components.RequestBodies[name] == nil {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+		body = doc.Components.RequestBodies[name]
+	}
+	return body, nil
+}
+
+// resolveSchema follows a schema's references to components/schemas, returning the schema
+// it ends at. Unresolvable references are left for the jsonschema converter to report.
+func resolveSchema(doc *Document, schema *jsonschema.Schema) *jsonschema.Schema {
+	seen := make(map[*jsonschema.Schema]bool)
+	for schema != nil && schema.Ref != "" && !seen[schema] {
+		seen[schema] = true
+		name, ok := strings.CutPrefix(schema.Ref, defsPrefix)
+		if !ok || doc.Components.Schemas[name] == nil {
+			break
+		}
+		schema = doc.Components.Schemas[name]
+	}
+	return schema
+}
+
+// isObjectSchema reports whether a body schema describes an object with properties
+func isObjectSchema(schema *jsonschema.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	typeName, types, _ := schema.GetType()
+	for _, t := range types {
+		if t != "null" {
+			typeName = t
+			break
+		}
+	}
+	return typeName == "object" || (typeName == "" && (schema.Properties != nil || len(schema.AllOf) > 0))
+}
+
+// operationServers returns the servers in effect for an operation
+func operationServers(doc *Document, item *PathItem, operation *Operation) []Server {
+	if len(operation.Servers) > 0 {
+		return operation.Servers
+	}
+	if len(item.Servers) > 0 {
+		return item.Servers
+	}
+	return doc.Servers
+}
+
+// serverURL returns the URL of the first server with its variables set to their defaults
+// and without a trailing slash, or "" if there is no server
+func serverURL(servers []Server) string {
+	if len(servers) == 0 {
+		return ""
+	}
+	url := servers[0].URL
+	for name, variable := range servers[0].Variables {
+		url = strings.ReplaceAll(url, "{"+name+"}", variable.Default)
+	}
+	return strings.TrimSuffix(url, "/")
+}
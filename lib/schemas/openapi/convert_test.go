@@ -0,0 +1,250 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+const petstore = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Petstore", "version": "1.0.0"},
+	"servers": [{"url": "https://{region}.example.com/v1/", "variables": {"region": {"default": "eu"}}}],
+	"paths": {
+		"/pets/{petId}": {
+			"parameters": [
+				{"name": "petId", "in": "path", "schema": {"type": "integer"}},
+				{"name": "X-Request-Id", "in": "header", "schema": {"type": "string"}}
+			],
+			"put": {
+				"operationId": "updatePet",
+				"summary": "Update a pet",
+				"parameters": [
+					{"$ref": "#/components/parameters/Notify"},
+					{"name": "dryRun", "in": "query", "description": "Validate only", "deprecated": true, "schema": {"type": "boolean"}}
+				],
+				"requestBody": {"$ref": "#/components/requestBodies/Pet"}
+			},
+			"get": {"operationId": "getPet"}
+		},
+		"/pets/{petId}/photo": {
+			"post": {
+				"operationId": "uploadPhoto",
+				"servers": [{"url": "/uploads"}],
+				"parameters": [{"name": "petId", "in": "path", "required": true, "schema": {"type": "integer"}}],
+				"requestBody": {
+					"required": true,
+					"description": "The photo caption",
+					"content": {"text/plain": {"schema": {"type": "string", "maxLength": 500}}}
+				}
+			}
+		}
+	},
+	"components": {
+		"parameters": {
+			"Notify": {"name": "notify", "in": "query", "required": true, "schema": {"type": "string", "enum": ["email", "sms"]}}
+		},
+		"requestBodies": {
+			"Pet": {"content": {
+				"application/xml": {"schema": {"type": "string"}},
+				"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}
+			}}
+		},
+		"schemas": {
+			"Pet": {
+				"type": "object",
+				"required": ["name"],
+				"properties": {
+					"name": {"type": "string", "title": "Name"},
+					"age": {"type": "integer", "minimum": 0, "exclusiveMinimum": true, "nullable": true},
+					"owner": {"$ref": "#/components/schemas/Owner"}
+				}
+			},
+			"Owner": {
+				"type": "object",
+				"properties": {"email": {"type": "string", "format": "email"}}
+			}
+		}
+	}
+}`
+
+func parsePetstore(t *testing.T) *Document {
+	t.Helper()
+	doc, err := Parse([]byte(petstore))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return doc
+}
+
+func fieldNames(fields []lib.Field) []string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	return names
+}
+
+func TestConvertOperationToForm(t *testing.T) {
+	form, err := ConvertOperationToForm(parsePetstore(t), "updatePet")
+	if err != nil {
+		t.Fatalf("ConvertOperationToForm() error = %v", err)
+	}
+	if err := form.Validate(); err != nil {
+		t.Fatalf("Form.Validate() error = %v", err)
+	}
+
+	if form.Action != "https://eu.example.com/v1/pets/{petId}" {
+		t.Errorf("Action = %q", form.Action)
+	}
+	if form.Method != "PUT" {
+		t.Errorf("Method = %q, want PUT", form.Method)
+	}
+	if form.Title != "Update a pet" {
+		t.Errorf("Title = %q, want the operation summary", form.Title)
+	}
+
+	wantNames := []string{"petId", "notify", "dryRun", "name", "age", "owner"}
+	if got := fieldNames(form.Fields); !reflect.DeepEqual(got, wantNames) {
+		t.Fatalf("field names = %v, want %v", got, wantNames)
+	}
+
+	petID := form.Fields[0]
+	if petID.DataType != lib.DataTypeInteger || petID.Validation == nil || !petID.Validation.Required {
+		t.Errorf("petId = %+v, want a required integer", petID)
+	}
+	notify := form.Fields[1]
+	if notify.Type != lib.FieldTypeRadio || !notify.Validation.Required {
+		t.Errorf("notify = %+v, want required radios", notify)
+	}
+	dryRun := form.Fields[2]
+	if dryRun.Type != lib.FieldTypeCheckbox || dryRun.Description != "Validate only" || !dryRun.Deprecated {
+		t.Errorf("dryRun = %+v, want a deprecated checkbox with the parameter description", dryRun)
+	}
+	name := form.Fields[3]
+	if name.Label != "Name" || name.Validation == nil || !name.Validation.Required {
+		t.Errorf("name = %+v, want the required body property", name)
+	}
+	age := form.Fields[4]
+	if age.Validation == nil || age.Validation.Min == nil || *age.Validation.Min != 0 || !age.Validation.ExclusiveMin {
+		t.Errorf("age = %+v, want the 3.0 exclusive minimum", age)
+	}
+	if errs := form.ValidateValues(map[string]any{"name": "Rex", "age": int64(0)}); errs["age"] != "Must be greater than 0" {
+		t.Errorf("ValidateValues() = %v, want age to exclude its minimum", errs)
+	}
+	owner := form.Fields[5]
+	if owner.Type != lib.FieldTypeObject || len(owner.Fields) != 1 || owner.Fields[0].Type != lib.FieldTypeEmail {
+		t.Errorf("owner = %+v, want the referenced Owner object", owner)
+	}
+}
+
+func TestConvertOperationToForm_NonObjectBody(t *testing.T) {
+	form, err := ConvertOperationToForm(parsePetstore(t), "uploadPhoto")
+	if err != nil {
+		t.Fatalf("ConvertOperationToForm() error = %v", err)
+	}
+
+	if form.Action != "/uploads/pets/{petId}/photo" || form.Method != "POST" {
+		t.Errorf("Action, Method = %q, %q", form.Action, form.Method)
+	}
+	if form.Description != "The photo caption" {
+		t.Errorf("Description = %q, want the request body description", form.Description)
+	}
+	if got := fieldNames(form.Fields); !reflect.DeepEqual(got, []string{"petId", BodyFieldName}) {
+		t.Fatalf("field names = %v", got)
+	}
+	body := form.Fields[1]
+	if body.Type != lib.FieldTypeTextarea || body.Validation == nil || !body.Validation.Required {
+		t.Errorf("body = %+v, want a required textarea", body)
+	}
+}
+
+func TestConvertOperationToForm_WithoutBody(t *testing.T) {
+	form, err := ConvertOperationToForm(parsePetstore(t), "getPet")
+	if err != nil {
+		t.Fatalf("ConvertOperationToForm() error = %v", err)
+	}
+	if got := fieldNames(form.Fields); !reflect.DeepEqual(got, []string{"petId"}) {
+		t.Errorf("field names = %v, want [petId]", got)
+	}
+	if form.Method != "GET" {
+		t.Errorf("Method = %q, want GET", form.Method)
+	}
+}
+
+func TestConvertOperationToForm_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        string
+		operationID string
+		wantErr     string
+	}{
+		{
+			name:        "unknown operation",
+			spec:        petstore,
+			operationID: "deletePet",
+			wantErr:     `operation "deletePet" not found`,
+		},
+		{
+			name: "duplicate operation ids",
+			spec: `{"openapi": "3.1.0", "paths": {
+				"/a": {"get": {"operationId": "list"}},
+				"/b": {"get": {"operationId": "list"}}
+			}}`,
+			operationID: "list",
+			wantErr:     `operationId "list" is not unique: GET /a, GET /b`,
+		},
+		{
+			name: "unresolvable parameter",
+			spec: `{"openapi": "3.1.0", "paths": {"/a": {"get": {
+				"operationId": "list",
+				"parameters": [{"$ref": "#/components/parameters/Missing"}]
+			}}}}`,
+			operationID: "list",
+			wantErr:     `operation "list": unresolvable $ref "#/components/parameters/Missing"`,
+		},
+		{
+			name: "parameter without schema",
+			spec: `{"openapi": "3.1.0", "paths": {"/a": {"get": {
+				"operationId": "list",
+				"parameters": [{"name": "q", "in": "query"}]
+			}}}}`,
+			operationID: "list",
+			wantErr:     `operation "list": parameter "q": missing schema`,
+		},
+		{
+			name: "parameter conflicting with body property",
+			spec: `{"openapi": "3.1.0", "paths": {"/a/{id}": {"post": {
+				"operationId": "create",
+				"parameters": [{"name": "id", "in": "path", "schema": {"type": "string"}}],
+				"requestBody": {"content": {"application/json": {"schema": {"type": "object", "properties": {"id": {"type": "string"}}}}}}
+			}}}}`,
+			operationID: "create",
+			wantErr:     `operation "create": parameter "id" conflicts with a request body property`,
+		},
+		{
+			name: "unresolvable schema",
+			spec: `{"openapi": "3.1.0", "paths": {"/a": {"post": {
+				"operationId": "create",
+				"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Missing"}}}}
+			}}}}`,
+			operationID: "create",
+			wantErr:     `unresolvable $ref "#/$defs/Missing"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse([]byte(tt.spec))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			_, err = ConvertOperationToForm(doc, tt.operationID)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ConvertOperationToForm() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
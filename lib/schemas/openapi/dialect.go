@@ -0,0 +1,280 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	componentSchemasPrefix = "#/components/schemas/"
+	defsPrefix             = "#/$defs/"
+)
+
+// Keywords of a schema whose values are subschemas, a map of subschemas or a list of them
+var (
+	schemaKeywords = map[string]bool{
+		"items": true, "not": true, "if": true, "then": true, "else": true, "contains": true,
+		"additionalProperties": true, "propertyNames": true, "unevaluatedItems": true,
+		"unevaluatedProperties": true, "contentSchema": true,
+	}
+	schemaMapKeywords = map[string]bool{
+		"properties": true, "patternProperties": true, "$defs": true, "definitions": true,
+		"dependentSchemas": true,
+	}
+	schemaListKeywords = map[string]bool{
+		"allOf": true, "anyOf": true, "oneOf": true, "prefixItems": true,
+	}
+)
+
+// member is a single key and value of a JSON object
+type member struct {
+	key   string
+	value json.RawMessage
+}
+
+// normalizeDocument normalizes every schema of an OpenAPI document: the values of "schema"
+// keys and the schemas of components/schemas. Examples are left as they are.
+func normalizeDocument(data []byte, legacy bool) ([]byte, error) {
+	members, ok, err := objectMembers(data)
+	if err != nil || !ok {
+		return data, err
+	}
+
+	for i, m := range members {
+		if m.key != "components" {
+			if members[i].value, err = normalizeObjects(m.value, legacy); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		components, ok, err := objectMembers(m.value)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		for j, component := range components {
+			if component.key == "schemas" {
+				components[j].value, err = mapValues(component.value, func(schema json.RawMessage) (json.RawMessage, error) {
+					return normalizeSchema(schema, legacy)
+				})
+			} else {
+				components[j].value, err = normalizeObjects(component.value, legacy)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("components.%s: %w", component.key, err)
+			}
+		}
+		members[i].value = encodeMembers(components)
+	}
+
+	return encodeMembers(members), nil
+}
+
+// normalizeObjects normalizes the schemas found under "schema" keys of a JSON value
+func normalizeObjects(data json.RawMessage, legacy bool) (json.RawMessage, error) {
+	if items, ok, err := arrayItems(data); err != nil || ok {
+		if err != nil {
+			return nil, err
+		}
+		for i := range items {
+			if items[i], err = normalizeObjects(items[i], legacy); err != nil {
+				return nil, err
+			}
+		}
+		return encodeItems(items), nil
+	}
+
+	members, ok, err := objectMembers(data)
+	if err != nil || !ok {
+		return data, err
+	}
+	for i, m := range members {
+		switch {
+		case m.key == "schema":
+			members[i].value, err = normalizeSchema(m.value, legacy)
+		case m.key == "example" || m.key == "examples" || strings.HasPrefix(m.key, "x-"):
+			continue
+		default:
+			members[i].value, err = normalizeObjects(m.value, legacy)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return encodeMembers(members), nil
+}
+
+// normalizeSchema rewrites a schema and its subschemas as JSON Schema 2020-12, translating
+// the OpenAPI 3.0 dialect if legacy is set
+func normalizeSchema(data json.RawMessage, legacy bool) (json.RawMessage, error) {
+	switch string(bytes.TrimSpace(data)) {
+	case "true":
+		return json.RawMessage(`{}`), nil
+	case "false":
+		return json.RawMessage(`{"not":{}}`), nil
+	}
+
+	members, ok, err := objectMembers(data)
+	if err != nil || !ok {
+		return data, err
+	}
+
+	nullable := false
+	exclusive := map[string]bool{}
+	kept := members[:0]
+	for _, m := range members {
+		switch {
+		case m.key == "$ref":
+			var ref string
+			if json.Unmarshal(m.value, &ref) == nil && strings.HasPrefix(ref, componentSchemasPrefix) {
+				m.value, _ = json.Marshal(defsPrefix + strings.TrimPrefix(ref, componentSchemasPrefix))
+			}
+		case schemaKeywords[m.key]:
+			m.value, err = normalizeSchema(m.value, legacy)
+		case schemaMapKeywords[m.key]:
+			m.value, err = mapValues(m.value, func(schema json.RawMessage) (json.RawMessage, error) {
+				return normalizeSchema(schema, legacy)
+			})
+		case schemaListKeywords[m.key]:
+			m.value, err = listValues(m.value, func(schema json.RawMessage) (json.RawMessage, error) {
+				return normalizeSchema(schema, legacy)
+			})
+		case legacy && m.key == "nullable":
+			if json.Unmarshal(m.value, &nullable) == nil {
+				continue
+			}
+		case legacy && (m.key == "exclusiveMinimum" || m.key == "exclusiveMaximum"):
+			var flag bool
+			if json.Unmarshal(m.value, &flag) == nil {
+				exclusive[m.key] = flag
+				continue
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.key, err)
+		}
+		kept = append(kept, m)
+	}
+	members = kept
+
+	for i, m := range members {
+		switch {
+		case m.key == "type" && nullable:
+			var typeName string
+			if json.Unmarshal(m.value, &typeName) == nil {
+				members[i].value, _ = json.Marshal([]string{typeName, "null"})
+			}
+		case m.key == "minimum" && exclusive["exclusiveMinimum"]:
+			members[i].key = "exclusiveMinimum"
+		case m.key == "maximum" && exclusive["exclusiveMaximum"]:
+			members[i].key = "exclusiveMaximum"
+		}
+	}
+
+	return encodeMembers(members), nil
+}
+
+// mapValues applies normalize to every value of a JSON object
+func mapValues(data json.RawMessage, normalize func(json.RawMessage) (json.RawMessage, error)) (json.RawMessage, error) {
+	members, ok, err := objectMembers(data)
+	if err != nil || !ok {
+		return data, err
+	}
+	for i, m := range members {
+		if members[i].value, err = normalize(m.value); err != nil {
+			return nil, fmt.Errorf("%s: %w", m.key, err)
+		}
+	}
+	return encodeMembers(members), nil
+}
+
+// listValues applies normalize to every item of a JSON array
+func listValues(data json.RawMessage, normalize func(json.RawMessage) (json.RawMessage, error)) (json.RawMessage, error) {
+	items, ok, err := arrayItems(data)
+	if err != nil || !ok {
+		return data, err
+	}
+	for i := range items {
+		if items[i], err = normalize(items[i]); err != nil {
+			return nil, fmt.Errorf("%d: %w", i, err)
+		}
+	}
+	return encodeItems(items), nil
+}
+
+// objectMembers returns the members of a JSON object in document order, reporting whether
+// data is an object
+func objectMembers(data []byte) ([]member, bool, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return nil, false, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return nil, false, err
+	}
+	var members []member
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, false, err
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, false, err
+		}
+		members = append(members, member{key: key, value: value})
+	}
+	return members, true, nil
+}
+
+// arrayItems returns the items of a JSON array, reporting whether data is an array
+func arrayItems(data []byte) ([]json.RawMessage, bool, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '[' {
+		return nil, false, nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, false, err
+	}
+	return items, true, nil
+}
+
+// encodeMembers encodes members as a JSON object, keeping their order
+func encodeMembers(members []member) json.RawMessage {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(m.key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(m.value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
+
+// encodeItems encodes items as a JSON array
+func encodeItems(items []json.RawMessage) json.RawMessage {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(item)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes()
+}
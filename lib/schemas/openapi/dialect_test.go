@@ -0,0 +1,91 @@
+package openapi
+
+import "testing"
+
+func TestNormalizeSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		legacy bool
+		want   string
+	}{
+		{
+			name:   "nullable type",
+			schema: `{"type": "string", "nullable": true}`,
+			legacy: true,
+			want:   `{"type":["string","null"]}`,
+		},
+		{
+			name:   "not nullable",
+			schema: `{"type": "string", "nullable": false}`,
+			legacy: true,
+			want:   `{"type":"string"}`,
+		},
+		{
+			name:   "exclusive bounds",
+			schema: `{"minimum": 1, "exclusiveMinimum": true, "maximum": 10, "exclusiveMaximum": false}`,
+			legacy: true,
+			want:   `{"exclusiveMinimum":1,"maximum":10}`,
+		},
+		{
+			name:   "3.1 keywords are kept",
+			schema: `{"type": "string", "nullable": true, "exclusiveMinimum": 1}`,
+			want:   `{"type":"string","nullable":true,"exclusiveMinimum":1}`,
+		},
+		{
+			name:   "component references",
+			schema: `{"$ref": "#/components/schemas/Pet/properties/name"}`,
+			want:   `{"$ref":"#/$defs/Pet/properties/name"}`,
+		},
+		{
+			name:   "other references are kept",
+			schema: `{"$ref": "#/$defs/Pet"}`,
+			want:   `{"$ref":"#/$defs/Pet"}`,
+		},
+		{
+			name:   "boolean schemas",
+			schema: `{"properties": {"any": true}, "additionalProperties": false}`,
+			want:   `{"properties":{"any":{}},"additionalProperties":{"not":{}}}`,
+		},
+		{
+			name:   "subschemas",
+			schema: `{"items": {"type": "integer", "nullable": true}, "oneOf": [{"$ref": "#/components/schemas/Cat"}], "properties": {"properties": {"type": "object", "nullable": true}}}`,
+			legacy: true,
+			want:   `{"items":{"type":["integer","null"]},"oneOf":[{"$ref":"#/$defs/Cat"}],"properties":{"properties":{"type":["object","null"]}}}`,
+		},
+		{
+			name:   "examples are kept",
+			schema: `{"examples": [{"$ref": "#/components/schemas/Pet"}], "default": {"nullable": true}}`,
+			legacy: true,
+			want:   `{"examples":[{"$ref": "#/components/schemas/Pet"}],"default":{"nullable": true}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeSchema([]byte(tt.schema), tt.legacy)
+			if err != nil {
+				t.Fatalf("normalizeSchema() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("normalizeSchema() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeDocument_LeavesExamples(t *testing.T) {
+	doc := `{"paths": {"/pets": {"post": {"requestBody": {"content": {"application/json": {
+		"schema": {"type": "string", "nullable": true},
+		"example": {"schema": {"nullable": true}}
+	}}}}}}}`
+
+	got, err := normalizeDocument([]byte(doc), true)
+	if err != nil {
+		t.Fatalf("normalizeDocument() error = %v", err)
+	}
+	want := `{"paths":{"/pets":{"post":{"requestBody":{"content":{"application/json":{"schema":{"type":["string","null"]},"example":{"schema": {"nullable": true}}}}}}}}}`
+	if string(got) != want {
+		t.Errorf("normalizeDocument() = %s, want %s", got, want)
+	}
+}
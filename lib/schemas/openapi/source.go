@@ -0,0 +1,24 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+func init() {
+	lib.RegisterSource("openapi", lib.SchemaSourceFunc(convert), "application/vnd.oai.openapi+json", "application/vnd.oai.openapi")
+}
+
+// convert is the schema source of OpenAPI documents, converting the operation whose
+// operationId is name
+func convert(data []byte, name string) (*lib.Form, error) {
+	if name == "" {
+		return nil, fmt.Errorf("an operationId is required to select the operation to convert")
+	}
+	doc, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertOperationToForm(doc, name)
+}
@@ -0,0 +1,152 @@
+// Package openapi generates forms for the operations of OpenAPI 3.0 and 3.1 documents. The
+// form of an operation holds its path and query parameters followed by its request body,
+// converted with the jsonschema package, and submits to the operation's path and method.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Olian04/form-from-schema/lib/schemas/jsonschema"
+)
+
+// Parse unmarshals an OpenAPI 3.0 or 3.1 document written in JSON or YAML, read like
+// jsonschema.ParseYAML reads schemas. Schemas are read as JSON Schema 2020-12: the OpenAPI
+// 3.0 dialect (nullable, boolean exclusiveMinimum/exclusiveMaximum) is translated, boolean
+// schemas are expanded and references to components/schemas are rewritten to the $defs of
+// the schemas ConvertOperationToForm builds.
+func Parse(data []byte) (*Document, error) {
+	data, err := jsonschema.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var header struct {
+		OpenAPI string `json:"openapi"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+	legacy := strings.HasPrefix(header.OpenAPI, "3.0.") || header.OpenAPI == "3.0"
+	if !legacy && !strings.HasPrefix(header.OpenAPI, "3.1.") && header.OpenAPI != "3.1" {
+		if header.OpenAPI == "" {
+			return nil, fmt.Errorf("missing openapi version")
+		}
+		return nil, fmt.Errorf("unsupported openapi version %q (must be 3.0 or 3.1)", header.OpenAPI)
+	}
+
+	normalized, err := normalizeDocument(data, legacy)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc Document
+	if err := json.Unmarshal(normalized, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// Document represents an OpenAPI document, limited to what forms are generated from
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths,omitempty"`
+	Components Components           `json:"components,omitempty"`
+}
+
+// Info holds the metadata of the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server is a base URL the API is served from
+type Server struct {
+	URL         string                    `json:"url"`
+	Description string                    `json:"description,omitempty"`
+	Variables   map[string]ServerVariable `json:"variables,omitempty"`
+}
+
+// ServerVariable is a variable substituted in a server URL
+type ServerVariable struct {
+	Default     string   `json:"default"`
+	Enum        []string `json:"enum,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// PathItem holds the operations available on a single path
+type PathItem struct {
+	Summary     string       `json:"summary,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Get         *Operation   `json:"get,omitempty"`
+	Put         *Operation   `json:"put,omitempty"`
+	Post        *Operation   `json:"post,omitempty"`
+	Delete      *Operation   `json:"delete,omitempty"`
+	Options     *Operation   `json:"options,omitempty"`
+	Head        *Operation   `json:"head,omitempty"`
+	Patch       *Operation   `json:"patch,omitempty"`
+	Trace       *Operation   `json:"trace,omitempty"`
+	Servers     []Server     `json:"servers,omitempty"`
+	Parameters  []*Parameter `json:"parameters,omitempty"`
+}
+
+// Operations returns the operations of the path item keyed by upper-case HTTP method
+func (p *PathItem) Operations() map[string]*Operation {
+	operations := make(map[string]*Operation)
+	for method, operation := range map[string]*Operation{
+		"GET": p.Get, "PUT": p.Put, "POST": p.Post, "DELETE": p.Delete,
+		"OPTIONS": p.Options, "HEAD": p.Head, "PATCH": p.Patch, "TRACE": p.Trace,
+	} {
+		if operation != nil {
+			operations[method] = operation
+		}
+	}
+	return operations
+}
+
+// Operation is a single API operation on a path
+type Operation struct {
+	OperationID string       `json:"operationId,omitempty"`
+	Summary     string       `json:"summary,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Deprecated  bool         `json:"deprecated,omitempty"`
+	Servers     []Server     `json:"servers,omitempty"`
+	Parameters  []*Parameter `json:"parameters,omitempty"`
+	RequestBody *RequestBody `json:"requestBody,omitempty"`
+}
+
+// Parameter is a single operation parameter, or a reference to one in components
+type Parameter struct {
+	Ref         string                `json:"$ref,omitempty"`
+	Name        string                `json:"name,omitempty"`
+	In          string                `json:"in,omitempty"` // "path", "query", "header" or "cookie"
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Schema      *jsonschema.Schema    `json:"schema,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// RequestBody is the body of an operation, or a reference to one in components
+type RequestBody struct {
+	Ref         string                `json:"$ref,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType describes content of a single media type
+type MediaType struct {
+	Schema *jsonschema.Schema `json:"schema,omitempty"`
+}
+
+// Components holds the reusable objects of the document
+type Components struct {
+	Schemas       map[string]*jsonschema.Schema `json:"schemas,omitempty"`
+	Parameters    map[string]*Parameter         `json:"parameters,omitempty"`
+	RequestBodies map[string]*RequestBody       `json:"requestBodies,omitempty"`
+}
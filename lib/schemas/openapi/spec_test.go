@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{name: "openapi 3.0", spec: `{"openapi": "3.0.3", "info": {"title": "Pets", "version": "1"}}`},
+		{name: "openapi 3.1", spec: `{"openapi": "3.1.0", "info": {"title": "Pets", "version": "1"}}`},
+		{name: "missing version", spec: `{"info": {"title": "Pets"}}`, wantErr: "missing openapi version"},
+		{name: "swagger 2.0", spec: `{"swagger": "2.0", "openapi": "2.0"}`, wantErr: `unsupported openapi version "2.0"`},
+		{name: "invalid json", spec: `{"openapi": `, wantErr: "line 1, column 13: unexpected end of document"},
+		{name: "yaml", spec: "openapi: 3.1.0\ninfo:\n  title: Pets\n  version: '1'\n"},
+		{name: "json with comments", spec: "{\n  // Pet store\n  openapi: '3.0.3',\n  info: {title: 'Pets', version: '1'},\n}"},
+		{name: "invalid yaml", spec: "openapi: 3.1.0\ninfo:\n  title: [Pets\n", wantErr: "line 2: did not find expected ',' or ']'"},
+		{
+			name:    "invalid schema",
+			spec:    `{"openapi": "3.1.0", "components": {"schemas": {"Pet": {"type": "object", "required": "name"}}}}`,
+			wantErr: "cannot unmarshal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse([]byte(tt.spec))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if doc.Info.Title != "Pets" {
+				t.Errorf("Parse() info title = %q, want %q", doc.Info.Title, "Pets")
+			}
+		})
+	}
+}
+
+func TestParse_Schemas(t *testing.T) {
+	doc, err := Parse([]byte(`{
+		"openapi": "3.0.3",
+		"paths": {
+			"/pets": {
+				"post": {
+					"requestBody": {
+						"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}
+					}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Pet": {
+					"type": "object",
+					"properties": {
+						"name": {"type": "string", "nullable": true},
+						"age": {"type": "integer", "minimum": 0, "exclusiveMinimum": true},
+						"tag": {"type": "string"}
+					},
+					"additionalProperties": false
+				}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	body := doc.Paths["/pets"].Post.RequestBody.Content["application/json"].Schema
+	if body.Ref != "#/$defs/Pet" {
+		t.Errorf("request body $ref = %q, want %q", body.Ref, "#/$defs/Pet")
+	}
+
+	pet := doc.Components.Schemas["Pet"]
+	if got := pet.OrderedPropertyNames(); !reflect.DeepEqual(got, []string{"name", "age", "tag"}) {
+		t.Errorf("property order = %v, want document order", got)
+	}
+	if _, types, _ := pet.Properties["name"].GetType(); !reflect.DeepEqual(types, []string{"string", "null"}) {
+		t.Errorf("nullable type = %v, want [string null]", types)
+	}
+	age := pet.Properties["age"]
+	if age.Minimum != nil || age.ExclusiveMinimum == nil || *age.ExclusiveMinimum != 0 {
+		t.Errorf("exclusive minimum = %v/%v, want exclusiveMinimum 0", age.Minimum, age.ExclusiveMinimum)
+	}
+	if pet.AdditionalProperties == nil || pet.AdditionalProperties.Not == nil {
+		t.Errorf("additionalProperties false = %+v, want {not: {}}", pet.AdditionalProperties)
+	}
+}
+
+func TestPathItem_Operations(t *testing.T) {
+	item := &PathItem{Get: &Operation{OperationID: "getPet"}, Delete: &Operation{OperationID: "deletePet"}}
+
+	operations := item.Operations()
+	methods := make([]string, 0, len(operations))
+	for method := range operations {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	if !reflect.DeepEqual(methods, []string{"DELETE", "GET"}) {
+		t.Errorf("PathItem.Operations() methods = %v, want [DELETE GET]", methods)
+	}
+	if operations["GET"].OperationID != "getPet" {
+		t.Errorf("PathItem.Operations()[GET] = %q, want getPet", operations["GET"].OperationID)
+	}
+}
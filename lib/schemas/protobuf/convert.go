@@ -0,0 +1,373 @@
+// Package protobuf generates forms from Protocol Buffers message descriptors, so the request
+// messages of gRPC methods can be edited without a parallel JSON Schema. Fields are named by
+// their JSON names, so submissions decode to the JSON mapping of the message:
+//
+//	form, err := protobuf.ConvertMessageToForm((&pb.CreateUserRequest{}).ProtoReflect().Descriptor())
+//
+// Scalars become inputs, enums select or radio options, messages nested objects and
+// repeated fields arrays. A oneof becomes a variant field named after the oneof, with a
+// variant per member; UnmarshalValues lifts the chosen member back into the message.
+package protobuf
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/Olian04/form-from-schema/lib"
+	"github.com/Olian04/form-from-schema/lib/schemas/jsonschema"
+)
+
+// durationPattern matches the JSON form of google.protobuf.Duration values
+const durationPattern = `^-?[0-9]+(\.[0-9]{1,9})?s$`
+
+// Options configures message to form conversion. The zero value converts like
+// ConvertMessageToForm.
+type Options struct {
+	// Label returns the labels of fields, oneofs and their variants from their names, and
+	// the labels of enum values as lib.ValueLabels generates them. lib.HumanizeLabel if nil;
+	// use a lib.Labeler for a dictionary of your own.
+	Label func(name string) string
+}
+
+// ConvertMessageToForm converts a message descriptor to a Form structure
+func ConvertMessageToForm(desc protoreflect.MessageDescriptor) (*lib.Form, error) {
+	return Options{}.Convert(desc)
+}
+
+// Convert converts a message descriptor to a Form structure according to the options, like
+// ConvertMessageToForm
+func (o Options) Convert(desc protoreflect.MessageDescriptor) (*lib.Form, error) {
+	if desc == nil {
+		return nil, fmt.Errorf("message descriptor cannot be nil")
+	}
+	if o.Label == nil {
+		o.Label = lib.HumanizeLabel
+	}
+
+	c := &converter{label: o.Label, converting: make(map[protoreflect.FullName]bool)}
+	fields, err := c.convertMessageFields(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lib.Form{
+		Description: comments(desc),
+		Method:      "POST", // Default
+		Fields:      fields,
+	}, nil
+}
+
+// ConvertDescriptorSetToForm converts the message with the given full name (e.g.
+// "acme.users.v1.CreateUserRequest") from a serialized FileDescriptorSet, as written by
+// protoc --descriptor_set_out with --include_imports, to a Form structure. Descriptions are
+// taken from the comments of the .proto files if the set includes source info.
+func ConvertDescriptorSetToForm(data []byte, messageName string) (*lib.Form, error) {
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid descriptor set: %w", err)
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor set: %w", err)
+	}
+
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(messageName))
+	if err != nil {
+		return nil, fmt.Errorf("message %q not found in descriptor set", messageName)
+	}
+	message, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a message", messageName)
+	}
+	return ConvertMessageToForm(message)
+}
+
+// converter holds the state shared by a single message to form conversion
+type converter struct {
+	label      func(string) string            // Generates the labels of fields and enum values
+	converting map[protoreflect.FullName]bool // Messages currently being converted, used to detect cycles
+}
+
+// convertMessageFields converts the fields of a message to form fields in declaration order.
+// The members of a oneof are grouped into a single variant field in place of the first member.
+func (c *converter) convertMessageFields(desc protoreflect.MessageDescriptor) ([]lib.Field, error) {
+	if c.converting[desc.FullName()] {
+		return nil, fmt.Errorf("recursive message %s", desc.FullName())
+	}
+	c.converting[desc.FullName()] = true
+	defer delete(c.converting, desc.FullName())
+
+	var fields []lib.Field
+	for i := range desc.Fields().Len() {
+		fd := desc.Fields().Get(i)
+
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			if oneof.Fields().Get(0) != fd {
+				continue
+			}
+			field, err := c.convertOneof(oneof)
+			if err != nil {
+				return nil, fmt.Errorf("error converting oneof %s: %w", oneof.Name(), err)
+			}
+			fields = append(fields, *field)
+			continue
+		}
+
+		field, err := c.convertField(fd)
+		if err != nil {
+			return nil, fmt.Errorf("error converting field %s: %w", fd.JSONName(), err)
+		}
+		fields = append(fields, *field)
+	}
+	return fields, nil
+}
+
+// convertOneof converts a oneof to a variant field with an object variant per member
+func (c *converter) convertOneof(oneof protoreflect.OneofDescriptor) (*lib.Field, error) {
+	field := &lib.Field{
+		Name:        oneofName(oneof),
+		Label:       c.label(oneofName(oneof)),
+		Description: comments(oneof),
+		Type:        lib.FieldTypeVariant,
+		DataType:    lib.DataTypeObject,
+	}
+
+	for i := range oneof.Fields().Len() {
+		fd := oneof.Fields().Get(i)
+		member, err := c.convertField(fd)
+		if err != nil {
+			return nil, fmt.Errorf("error converting field %s: %w", fd.JSONName(), err)
+		}
+		field.Variants = append(field.Variants, lib.Variant{
+			Label:  c.label(string(fd.Name())),
+			Value:  fd.JSONName(),
+			Fields: []lib.Field{*member},
+		})
+	}
+	return field, nil
+}
+
+// convertField converts a field descriptor to a form field
+func (c *converter) convertField(fd protoreflect.FieldDescriptor) (*lib.Field, error) {
+	if fd.IsMap() {
+		return nil, fmt.Errorf("unsupported map field")
+	}
+
+	field, err := c.convertValue(fd.JSONName(), fd)
+	if err != nil {
+		return nil, err
+	}
+
+	field.Label = c.label(fd.JSONName())
+	field.Description = comments(fd)
+	if options, ok := fd.Options().(*descriptorpb.FieldOptions); ok {
+		field.Deprecated = options.GetDeprecated()
+	}
+
+	if fd.IsList() {
+		item := *field
+		item.Name = "item"
+		item.Label = c.label(item.Name)
+		item.Description = ""
+		item.Deprecated = false
+		field = &lib.Field{
+			Name:        fd.JSONName(),
+			Label:       field.Label,
+			Description: field.Description,
+			Deprecated:  field.Deprecated,
+			Type:        lib.FieldTypeArray,
+			DataType:    lib.DataTypeArray,
+			Fields:      []lib.Field{item},
+		}
+	}
+
+	if fd.Cardinality() == protoreflect.Required {
+		if field.Validation == nil {
+			field.Validation = &lib.Validation{}
+		}
+		field.Validation.Required = true
+	}
+	return field, nil
+}
+
+// convertValue converts the value of a singular field, or of an item of a repeated field,
+// to a form field
+func (c *converter) convertValue(name string, fd protoreflect.FieldDescriptor) (*lib.Field, error) {
+	field := &lib.Field{Name: name}
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		field.Type = lib.FieldTypeText
+		field.DataType = lib.DataTypeString
+	case protoreflect.BoolKind:
+		field.Type = lib.FieldTypeCheckbox
+		field.DataType = lib.DataTypeBoolean
+	case protoreflect.BytesKind:
+		// Bytes are encoded as base64 strings, the way file uploads are decoded
+		field.Type = lib.FieldTypeFile
+		field.DataType = lib.DataTypeString
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		field.Type = lib.FieldTypeNumber
+		field.DataType = lib.DataTypeNumber
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		setInteger(field, math.MinInt32, math.MaxInt32)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		setInteger(field, 0, math.MaxUint32)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		setInteger(field, math.Inf(-1), math.Inf(1))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		setInteger(field, 0, math.Inf(1))
+
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]any, values.Len())
+		for i := range values.Len() {
+			names[i] = string(values.Get(i).Name())
+		}
+		for i, label := range lib.ValueLabels(names, c.label) {
+			field.Options = append(field.Options, lib.Option{Label: label, Value: names[i]})
+		}
+		field.DataType = lib.DataTypeString
+		if len(field.Options) <= jsonschema.DefaultRadioThreshold {
+			field.Type = lib.FieldTypeRadio
+		} else {
+			field.Type = lib.FieldTypeSelect
+		}
+
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if wellKnown, ok, err := convertWellKnown(name, fd.Message()); ok {
+			return wellKnown, err
+		}
+		nested, err := c.convertMessageFields(fd.Message())
+		if err != nil {
+			return nil, err
+		}
+		field.Type = lib.FieldTypeObject
+		field.DataType = lib.DataTypeObject
+		field.Fields = nested
+		return field, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported kind %s", fd.Kind())
+	}
+
+	if fd.HasDefault() {
+		field.Default = defaultValue(fd)
+	}
+	return field, nil
+}
+
+// convertWellKnown converts a field of one of the well-known message types that have a JSON
+// form of their own, reporting whether the message is one of them
+func convertWellKnown(name string, desc protoreflect.MessageDescriptor) (*lib.Field, bool, error) {
+	field := &lib.Field{Name: name}
+
+	switch desc.FullName() {
+	case "google.protobuf.Timestamp":
+		field.Type = lib.FieldTypeDateTime
+		field.DataType = lib.DataTypeString
+	case "google.protobuf.Duration":
+		field.Type = lib.FieldTypeText
+		field.DataType = lib.DataTypeString
+		field.Placeholder = "1.5s"
+		field.Validation = &lib.Validation{Pattern: durationPattern, PatternError: "Must be a number of seconds ending in s, like 1.5s"}
+	case "google.protobuf.FieldMask":
+		field.Type = lib.FieldTypeText
+		field.DataType = lib.DataTypeString
+		field.Placeholder = "path.to.field,other_field"
+	case "google.protobuf.StringValue":
+		field.Type = lib.FieldTypeText
+		field.DataType = lib.DataTypeString
+	case "google.protobuf.BoolValue":
+		field.Type = lib.FieldTypeCheckbox
+		field.DataType = lib.DataTypeBoolean
+	case "google.protobuf.BytesValue":
+		field.Type = lib.FieldTypeFile
+		field.DataType = lib.DataTypeString
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue":
+		field.Type = lib.FieldTypeNumber
+		field.DataType = lib.DataTypeNumber
+	case "google.protobuf.Int32Value":
+		setInteger(field, math.MinInt32, math.MaxInt32)
+	case "google.protobuf.UInt32Value":
+		setInteger(field, 0, math.MaxUint32)
+	case "google.protobuf.Int64Value":
+		setInteger(field, math.Inf(-1), math.Inf(1))
+	case "google.protobuf.UInt64Value":
+		setInteger(field, 0, math.Inf(1))
+	case "google.protobuf.Any", "google.protobuf.Struct", "google.protobuf.Value",
+		"google.protobuf.ListValue", "google.protobuf.Empty":
+		return nil, true, fmt.Errorf("unsupported message %s", desc.FullName())
+	default:
+		return nil, false, nil
+	}
+	return field, true, nil
+}
+
+// setInteger makes field an integer number field limited to the range of its type; infinite
+// bounds are left out
+func setInteger(field *lib.Field, min, max float64) {
+	field.Type = lib.FieldTypeNumber
+	field.DataType = lib.DataTypeInteger
+
+	validation := &lib.Validation{}
+	if !math.IsInf(min, 0) {
+		validation.Min = &min
+	}
+	if !math.IsInf(max, 0) {
+		validation.Max = &max
+	}
+	if validation.Min != nil || validation.Max != nil {
+		field.Validation = validation
+	}
+}
+
+// defaultValue returns the explicit default of a proto2 field the way its JSON form holds it
+func defaultValue(fd protoreflect.FieldDescriptor) any {
+	value := fd.Default()
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if enumValue := fd.DefaultEnumValue(); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return nil
+	case protoreflect.BytesKind:
+		return nil
+	}
+	return value.Interface()
+}
+
+// comments returns the leading comments of a declaration, if its file was compiled with
+// source info
+func comments(desc protoreflect.Descriptor) string {
+	file := desc.ParentFile()
+	if file == nil {
+		return ""
+	}
+	return strings.TrimSpace(file.SourceLocations().ByDescriptor(desc).LeadingComments)
+}
+
+// oneofName returns the name of a oneof in lowerCamelCase, the way JSON names of fields are formed
+func oneofName(oneof protoreflect.OneofDescriptor) string {
+	var name strings.Builder
+	upper := false
+	for _, r := range string(oneof.Name()) {
+		switch {
+		case r == '_':
+			upper = true
+		case upper && 'a' <= r && r <= 'z':
+			name.WriteRune(r - 'a' + 'A')
+			upper = false
+		default:
+			name.WriteRune(r)
+			upper = false
+		}
+	}
+	return name.String()
+}
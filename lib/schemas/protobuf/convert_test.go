@@ -0,0 +1,296 @@
+package protobuf
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+const usersProto = `
+name: "test/users.proto"
+package: "test.users"
+dependency: "google/protobuf/timestamp.proto"
+dependency: "google/protobuf/duration.proto"
+dependency: "google/protobuf/wrappers.proto"
+syntax: "proto3"
+message_type {
+  name: "CreateUserRequest"
+  field { name: "display_name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "displayName" }
+  field { name: "age" number: 2 label: LABEL_OPTIONAL type: TYPE_UINT32 json_name: "age" }
+  field { name: "role" number: 3 label: LABEL_OPTIONAL type: TYPE_ENUM type_name: ".test.users.Role" json_name: "role" }
+  field { name: "tags" number: 4 label: LABEL_REPEATED type: TYPE_STRING json_name: "tags" }
+  field { name: "address" number: 5 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".test.users.Address" json_name: "address" }
+  field { name: "email" number: 6 label: LABEL_OPTIONAL type: TYPE_STRING oneof_index: 0 json_name: "email" }
+  field { name: "phone" number: 7 label: LABEL_OPTIONAL type: TYPE_STRING oneof_index: 0 json_name: "phone" }
+  field { name: "expires_at" number: 8 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".google.protobuf.Timestamp" json_name: "expiresAt" }
+  field { name: "ttl" number: 9 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".google.protobuf.Duration" json_name: "ttl" }
+  field { name: "nickname" number: 10 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".google.protobuf.StringValue" json_name: "nickname" }
+  field { name: "avatar" number: 11 label: LABEL_OPTIONAL type: TYPE_BYTES json_name: "avatar" options { deprecated: true } }
+  field { name: "score" number: 12 label: LABEL_OPTIONAL type: TYPE_INT64 json_name: "score" oneof_index: 1 proto3_optional: true }
+  field { name: "previous" number: 13 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".test.users.Address" json_name: "previous" }
+  oneof_decl { name: "contact_method" }
+  oneof_decl { name: "_score" }
+}
+message_type {
+  name: "Address"
+  field { name: "street" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "street" }
+  field { name: "post_code" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "postCode" oneof_index: 0 }
+  field { name: "po_box" number: 3 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "poBox" oneof_index: 0 }
+  oneof_decl { name: "delivery" }
+}
+enum_type {
+  name: "Role"
+  value { name: "ROLE_UNSPECIFIED" number: 0 }
+  value { name: "ROLE_ADMIN" number: 1 }
+  value { name: "ROLE_EDITOR" number: 2 }
+  value { name: "ROLE_VIEWER" number: 3 }
+}
+source_code_info {
+  location { path: [4, 0] span: [0, 0, 0] leading_comments: " Creates a user.\n" }
+  location { path: [4, 0, 2, 0] span: [0, 0, 0] leading_comments: " Name shown to other users\n" }
+  location { path: [4, 0, 8, 0] span: [0, 0, 0] leading_comments: " How to reach the user\n" }
+}
+`
+
+// usersFile returns the descriptor proto of the test file
+func usersFile(t *testing.T) *descriptorpb.FileDescriptorProto {
+	t.Helper()
+	var file descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(usersProto), &file); err != nil {
+		t.Fatal(err)
+	}
+	return &file
+}
+
+// createUserRequest returns the descriptor of the test request message
+func createUserRequest(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	file, err := protodesc.NewFile(usersFile(t), protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file.Messages().ByName("CreateUserRequest")
+}
+
+func fieldNames(fields []lib.Field) []string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	return names
+}
+
+func TestConvertMessageToForm(t *testing.T) {
+	form, err := ConvertMessageToForm(createUserRequest(t))
+	if err != nil {
+		t.Fatalf("ConvertMessageToForm() error = %v", err)
+	}
+	if err := form.Validate(); err != nil {
+		t.Fatalf("Form.Validate() error = %v", err)
+	}
+
+	if form.Description != "Creates a user." || form.Method != "POST" {
+		t.Errorf("Description, Method = %q, %q", form.Description, form.Method)
+	}
+
+	wantNames := []string{"displayName", "age", "role", "tags", "address", "contactMethod", "expiresAt", "ttl", "nickname", "avatar", "score", "previous"}
+	if got := fieldNames(form.Fields); !reflect.DeepEqual(got, wantNames) {
+		t.Fatalf("field names = %v, want %v", got, wantNames)
+	}
+	fields := make(map[string]lib.Field)
+	for _, field := range form.Fields {
+		fields[field.Name] = field
+	}
+
+	tests := []struct {
+		name     string
+		field    string
+		check    func(lib.Field) bool
+		wantDesc string
+	}{
+		{name: "string", field: "displayName", check: func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeText && f.DataType == lib.DataTypeString
+		}, wantDesc: "Name shown to other users"},
+		{name: "unsigned integer", field: "age", check: func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeNumber && f.DataType == lib.DataTypeInteger &&
+				*f.Validation.Min == 0 && *f.Validation.Max == 4294967295
+		}},
+		{name: "enum", field: "role", check: func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeSelect && len(f.Options) == 4 && f.Options[1].Value == "ROLE_ADMIN" && f.Options[1].Label == "Role admin"
+		}},
+		{name: "repeated", field: "tags", check: func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeArray && f.Label == "Tags" && len(f.Fields) == 1 && f.Fields[0].Name == "item" && f.Fields[0].Label == "Item" && f.Fields[0].Type == lib.FieldTypeText
+		}},
+		{name: "nested message", field: "address", check: func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeObject && reflect.DeepEqual(fieldNames(f.Fields), []string{"street", "delivery"})
+		}},
+		{name: "oneof", field: "contactMethod", check: func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeVariant && len(f.Variants) == 2 &&
+				f.Variants[0].Label == "Email" && f.Label == "Contact method" && f.Variants[0].Value == "email" && f.Variants[0].Fields[0].Name == "email" &&
+				f.Variants[1].Value == "phone" && !f.Variants[1].Scalar
+		}, wantDesc: "How to reach the user"},
+		{name: "timestamp", field: "expiresAt", check: func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeDateTime && f.DataType == lib.DataTypeString
+		}},
+		{name: "duration", field: "ttl", check: func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeText && f.Validation.Pattern == durationPattern
+		}},
+		{name: "wrapper", field: "nickname", check: func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeText && f.DataType == lib.DataTypeString
+		}},
+		{name: "deprecated bytes", field: "avatar", check: func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeFile && f.Deprecated
+		}},
+		{name: "proto3 optional", field: "score", check: func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeNumber && f.DataType == lib.DataTypeInteger && f.Validation == nil
+		}},
+		{name: "repeated message", field: "previous", check: func(f lib.Field) bool {
+			return f.Type == lib.FieldTypeArray && f.Fields[0].Type == lib.FieldTypeObject
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := fields[tt.field]
+			if !tt.check(field) {
+				t.Errorf("field %s = %+v", tt.field, field)
+			}
+			if field.Description != tt.wantDesc {
+				t.Errorf("field %s description = %q, want %q", tt.field, field.Description, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestOptions_Label(t *testing.T) {
+	label := lib.Labeler{Words: map[string]string{"role": "permission"}}.Label
+	form, err := Options{Label: label}.Convert(createUserRequest(t))
+	if err != nil {
+		t.Fatalf("Options.Convert() error = %v", err)
+	}
+
+	role := form.Fields[2]
+	var got []string
+	got = append(got, role.Label)
+	for _, option := range role.Options {
+		got = append(got, option.Label)
+	}
+	want := []string{"Permission", "Permission unspecified", "Permission admin", "Permission editor", "Permission viewer"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("role labels = %q, want %q", got, want)
+	}
+}
+
+func TestConvertMessageToForm_Proto2(t *testing.T) {
+	form, err := ConvertMessageToForm((&descriptorpb.UninterpretedOption_NamePart{}).ProtoReflect().Descriptor())
+	if err != nil {
+		t.Fatalf("ConvertMessageToForm() error = %v", err)
+	}
+	for _, field := range form.Fields {
+		if field.Validation == nil || !field.Validation.Required {
+			t.Errorf("field %s = %+v, want required", field.Name, field)
+		}
+	}
+
+	form, err = ConvertMessageToForm((&descriptorpb.FieldOptions{}).ProtoReflect().Descriptor())
+	if err != nil {
+		t.Fatalf("ConvertMessageToForm() error = %v", err)
+	}
+	for _, field := range form.Fields {
+		if field.Name == "ctype" && field.Default != "STRING" {
+			t.Errorf("ctype default = %v, want STRING", field.Default)
+		}
+		if field.Name == "lazy" && field.Default != false {
+			t.Errorf("lazy default = %v, want false", field.Default)
+		}
+	}
+}
+
+func TestConvertMessageToForm_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		desc    protoreflect.MessageDescriptor
+		wantErr string
+	}{
+		{name: "nil", desc: nil, wantErr: "message descriptor cannot be nil"},
+		{
+			name:    "recursive message",
+			desc:    (&descriptorpb.DescriptorProto{}).ProtoReflect().Descriptor(),
+			wantErr: "recursive message google.protobuf.DescriptorProto",
+		},
+		{
+			name:    "map field",
+			desc:    (&structpb.Struct{}).ProtoReflect().Descriptor(),
+			wantErr: "error converting field fields: unsupported map field",
+		},
+		{
+			name:    "unsupported well-known type",
+			desc:    (&structpb.ListValue{}).ProtoReflect().Descriptor(),
+			wantErr: "unsupported message google.protobuf.Value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertMessageToForm(tt.desc)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ConvertMessageToForm() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConvertDescriptorSetToForm(t *testing.T) {
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(timestamppb.File_google_protobuf_timestamp_proto),
+		protodesc.ToFileDescriptorProto(durationpb.File_google_protobuf_duration_proto),
+		protodesc.ToFileDescriptorProto(wrapperspb.File_google_protobuf_wrappers_proto),
+		usersFile(t),
+	}}
+	data, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form, err := ConvertDescriptorSetToForm(data, "test.users.CreateUserRequest")
+	if err != nil {
+		t.Fatalf("ConvertDescriptorSetToForm() error = %v", err)
+	}
+	if len(form.Fields) != 12 || form.Fields[0].Description != "Name shown to other users" {
+		t.Errorf("ConvertDescriptorSetToForm() fields = %v", fieldNames(form.Fields))
+	}
+
+	for _, tt := range []struct {
+		data    []byte
+		message string
+		wantErr string
+	}{
+		{data: data, message: "test.users.DeleteUserRequest", wantErr: `message "test.users.DeleteUserRequest" not found in descriptor set`},
+		{data: data, message: "test.users.Role", wantErr: `"test.users.Role" is not a message`},
+		{data: []byte("not a descriptor set"), message: "test.users.CreateUserRequest", wantErr: "invalid descriptor set"},
+	} {
+		if _, err := ConvertDescriptorSetToForm(tt.data, tt.message); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ConvertDescriptorSetToForm(%q) error = %v, want error containing %q", tt.message, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOneofName(t *testing.T) {
+	desc := createUserRequest(t)
+	if got := oneofName(desc.Oneofs().ByName("contact_method")); got != "contactMethod" {
+		t.Errorf("oneofName() = %q, want contactMethod", got)
+	}
+}
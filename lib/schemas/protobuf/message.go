@@ -0,0 +1,74 @@
+package protobuf
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// UnmarshalValues stores decoded values of a form generated by ConvertMessageToForm in m,
+// lifting the member chosen for each oneof out of its variant field into the message the
+// way the JSON mapping of the message holds it
+func UnmarshalValues(values map[string]any, m proto.Message) error {
+	if m == nil {
+		return fmt.Errorf("message cannot be nil")
+	}
+	lifted := liftOneofs(values, m.ProtoReflect().Descriptor())
+
+	data, err := json.Marshal(lifted)
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(data, m)
+}
+
+// liftOneofs returns a copy of the values of a message with the members of its oneofs, and
+// of the oneofs of its nested messages, moved out of their variant fields
+func liftOneofs(values map[string]any, desc protoreflect.MessageDescriptor) map[string]any {
+	lifted := make(map[string]any, len(values))
+	for name, value := range values {
+		lifted[name] = value
+	}
+
+	for i := range desc.Oneofs().Len() {
+		oneof := desc.Oneofs().Get(i)
+		if oneof.IsSynthetic() {
+			continue
+		}
+		name := oneofName(oneof)
+		members, ok := lifted[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		delete(lifted, name)
+		for member, value := range members {
+			lifted[member] = value
+		}
+	}
+
+	for i := range desc.Fields().Len() {
+		fd := desc.Fields().Get(i)
+		if fd.Message() == nil || fd.IsMap() {
+			continue
+		}
+		switch value := lifted[fd.JSONName()].(type) {
+		case map[string]any:
+			lifted[fd.JSONName()] = liftOneofs(value, fd.Message())
+		case []any:
+			items := make([]any, len(value))
+			for j, item := range value {
+				if nested, ok := item.(map[string]any); ok {
+					items[j] = liftOneofs(nested, fd.Message())
+				} else {
+					items[j] = item
+				}
+			}
+			lifted[fd.JSONName()] = items
+		}
+	}
+
+	return lifted
+}
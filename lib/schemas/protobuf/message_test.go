@@ -0,0 +1,58 @@
+package protobuf
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestUnmarshalValues(t *testing.T) {
+	desc := createUserRequest(t)
+	message := dynamicpb.NewMessage(desc)
+
+	values := map[string]any{
+		"displayName":   "Ada",
+		"age":           int64(36),
+		"role":          "ROLE_ADMIN",
+		"tags":          []any{"math"},
+		"contactMethod": map[string]any{"phone": "555-0100"},
+		"address":       map[string]any{"street": "1 Main St", "delivery": map[string]any{"poBox": "42"}},
+		"previous":      []any{map[string]any{"delivery": map[string]any{"postCode": "E1"}}},
+		"expiresAt":     "2030-01-02T03:04:05Z",
+		"ttl":           "1.5s",
+		"nickname":      "countess",
+		"avatar":        "aGk=",
+		"score":         int64(7),
+	}
+	if err := UnmarshalValues(values, message); err != nil {
+		t.Fatalf("UnmarshalValues() error = %v", err)
+	}
+
+	got, err := protojson.MarshalOptions{UseProtoNames: false}.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want = dynamicpb.NewMessage(desc)
+	if err := protojson.Unmarshal([]byte(`{
+		"displayName": "Ada", "age": 36, "role": "ROLE_ADMIN", "tags": ["math"], "phone": "555-0100",
+		"address": {"street": "1 Main St", "poBox": "42"}, "previous": [{"postCode": "E1"}],
+		"expiresAt": "2030-01-02T03:04:05Z", "ttl": "1.5s", "nickname": "countess", "avatar": "aGk=", "score": "7"
+	}`), want); err != nil {
+		t.Fatal(err)
+	}
+	wantJSON, _ := protojson.Marshal(want)
+	if string(got) != string(wantJSON) {
+		t.Errorf("UnmarshalValues() = %s, want %s", got, wantJSON)
+	}
+}
+
+func TestUnmarshalValues_Invalid(t *testing.T) {
+	message := dynamicpb.NewMessage(createUserRequest(t))
+	if err := UnmarshalValues(map[string]any{"role": "ROLE_OWNER"}, message); err == nil {
+		t.Error("UnmarshalValues() error = nil, want error for an unknown enum value")
+	}
+	if err := UnmarshalValues(map[string]any{}, nil); err == nil {
+		t.Error("UnmarshalValues(nil) error = nil, want error")
+	}
+}
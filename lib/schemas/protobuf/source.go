@@ -0,0 +1,20 @@
+package protobuf
+
+import (
+	"fmt"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+func init() {
+	lib.RegisterSource("protobuf", lib.SchemaSourceFunc(convert), "application/x-protobuf")
+}
+
+// convert is the schema source of compiled descriptor sets, converting the message whose
+// full name is name
+func convert(data []byte, name string) (*lib.Form, error) {
+	if name == "" {
+		return nil, fmt.Errorf("a message name is required to select the message to convert")
+	}
+	return ConvertDescriptorSetToForm(data, name)
+}
@@ -0,0 +1,56 @@
+package html
+
+import (
+	"strconv"
+
+	"github.com/Olian04/form-from-schema/lib"
+	"github.com/a-h/templ"
+)
+
+// arrayItems returns the item fields an array field is rendered with: one per current item,
+// padded with empty items up to MinItems, and at least one so an item can be entered
+func arrayItems(field *lib.Field) []lib.Field {
+	items := append([]lib.Field(nil), field.ItemFields()...)
+	if len(field.Fields) != 1 {
+		return items
+	}
+
+	count := max(minItems(field), 1)
+	for len(items) < count {
+		items = append(items, field.Fields[0])
+	}
+	return items
+}
+
+// arrayAttributes returns the item count bounds of an array field for the array script
+func arrayAttributes(field *lib.Field) templ.Attributes {
+	attrs := templ.Attributes{}
+	if field.Validation != nil && field.Validation.MinItems != nil {
+		attrs["data-min-items"] = strconv.Itoa(*field.Validation.MinItems)
+	}
+	if field.Validation != nil && field.Validation.MaxItems != nil {
+		attrs["data-max-items"] = strconv.Itoa(*field.Validation.MaxItems)
+	}
+	return attrs
+}
+
+// canAddItem reports whether an item can be added to an array field with count items
+func canAddItem(field *lib.Field, count int) bool {
+	if len(field.Fields) != 1 {
+		return false
+	}
+	return field.Validation == nil || field.Validation.MaxItems == nil || count < *field.Validation.MaxItems
+}
+
+// canRemoveItem reports whether an item can be removed from an array field with count items
+func canRemoveItem(field *lib.Field, count int) bool {
+	return count > minItems(field)
+}
+
+// minItems returns the minimum number of items of an array field
+func minItems(field *lib.Field) int {
+	if field.Validation == nil || field.Validation.MinItems == nil {
+		return 0
+	}
+	return *field.Validation.MinItems
+}
@@ -0,0 +1,167 @@
+package html
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Olian04/form-from-schema/lib"
+	"github.com/a-h/templ"
+)
+
+// patternErrorID returns the id of the element describing a field's pattern error
+func patternErrorID(name fieldName) string {
+	return name.ID + "-pattern-error"
+}
+
+// inputAttributes returns the constraint validation and accessibility attributes of a field's input
+func inputAttributes(field *lib.Field, name fieldName) templ.Attributes {
+	attrs := constraintAttributes(field)
+
+	var describedBy []string
+	if hasPatternError(field) {
+		describedBy = append(describedBy, patternErrorID(name))
+	}
+	if field.Error != "" {
+		attrs["aria-invalid"] = "true"
+		describedBy = append(describedBy, errorID(name))
+	}
+	if len(describedBy) > 0 {
+		attrs["aria-describedby"] = strings.Join(describedBy, " ")
+	}
+
+	return attrs
+}
+
+// boundAttribute returns the value of the min or max attribute for a bound. HTML bounds are
+// inclusive, so an exclusive bound of an integer moves to the nearest whole number within
+// it (direction 1 for a minimum, -1 for a maximum); that of a number stays as is and is
+// only enforced on the server.
+func boundAttribute(bound float64, exclusive, integer bool, direction float64) float64 {
+	if !exclusive || !integer {
+		return bound
+	}
+	if direction > 0 {
+		return math.Floor(bound) + 1
+	}
+	return math.Ceil(bound) - 1
+}
+
+// stepMinimum returns the smallest multiple of step at or above min. Browsers count steps
+// from the min attribute, while multipleOf counts them from 0, so a min that is not a
+// multiple of the step is rounded up to one.
+func stepMinimum(min, step float64) float64 {
+	quotient := min / step
+	if math.Abs(quotient-math.Round(quotient)) < 1e-9 {
+		return min
+	}
+	// Drop the rounding error of the multiplication, as in 3 * 0.1
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(math.Ceil(quotient)*step, 'g', 15, 64), 64)
+	return rounded
+}
+
+// constraintAttributes maps a field's validation rules to HTML constraint validation
+// attributes, emitting only the attributes the browser honors for the field's type
+func constraintAttributes(field *lib.Field) templ.Attributes {
+	attrs := templ.Attributes{}
+	validation := field.Validation
+
+	// Number inputs step by 1 by default, which would reject fractional numbers
+	if field.Type == lib.FieldTypeNumber && field.DataType != lib.DataTypeInteger && (validation == nil || validation.Step == nil) {
+		attrs["step"] = "any"
+	}
+	if validation == nil {
+		return attrs
+	}
+
+	if validation.Required && supportsRequired(field.Type) {
+		attrs["required"] = true
+	}
+	if validation.RequiredWhen != nil && supportsRequired(field.Type) {
+		attrs["data-required-when"] = conditionJSON(*validation.RequiredWhen)
+	}
+
+	if supportsLength(field.Type) {
+		if validation.MinLength != nil {
+			attrs["minlength"] = strconv.Itoa(*validation.MinLength)
+		}
+		if validation.MaxLength != nil {
+			attrs["maxlength"] = strconv.Itoa(*validation.MaxLength)
+		}
+	}
+
+	if field.Type == lib.FieldTypeNumber {
+		integer := field.DataType == lib.DataTypeInteger
+		if validation.Min != nil {
+			min := boundAttribute(*validation.Min, validation.ExclusiveMin, integer, 1)
+			if validation.Step != nil {
+				min = stepMinimum(min, *validation.Step)
+			}
+			attrs["min"] = formatFloat(min)
+		}
+		if validation.Max != nil {
+			attrs["max"] = formatFloat(boundAttribute(*validation.Max, validation.ExclusiveMax, integer, -1))
+		}
+		if validation.Step != nil {
+			attrs["step"] = formatFloat(*validation.Step)
+		}
+	}
+
+	if validation.Pattern != "" && supportsPattern(field.Type) {
+		if pattern, ok := patternAttribute(validation.Pattern); ok {
+			attrs["pattern"] = pattern
+			if validation.PatternError != "" {
+				attrs["title"] = validation.PatternError
+			}
+		}
+	}
+
+	return attrs
+}
+
+// hasPatternError reports whether a pattern error description is rendered for the field
+func hasPatternError(field *lib.Field) bool {
+	if field.Validation == nil || field.Validation.PatternError == "" || !supportsPattern(field.Type) {
+		return false
+	}
+	_, ok := patternAttribute(field.Validation.Pattern)
+	return field.Validation.Pattern != "" && ok
+}
+
+// supportsRequired reports whether the required attribute applies to the field type
+func supportsRequired(fieldType lib.FieldType) bool {
+	switch fieldType {
+	case lib.FieldTypeHidden, lib.FieldTypeObject, lib.FieldTypeArray:
+		return false
+	case lib.FieldTypeCheckbox:
+		// A boolean checkbox is valid unchecked ("false"), and on a checkbox group the
+		// attribute would require every option to be checked
+		return false
+	}
+	return true
+}
+
+// supportsLength reports whether minlength/maxlength apply to the field type
+func supportsLength(fieldType lib.FieldType) bool {
+	switch fieldType {
+	case lib.FieldTypeText, lib.FieldTypeEmail, lib.FieldTypePassword,
+		lib.FieldTypeURL, lib.FieldTypeTel, lib.FieldTypeTextarea:
+		return true
+	}
+	return false
+}
+
+// supportsPattern reports whether the pattern attribute applies to the field type
+func supportsPattern(fieldType lib.FieldType) bool {
+	switch fieldType {
+	case lib.FieldTypeText, lib.FieldTypeEmail, lib.FieldTypePassword,
+		lib.FieldTypeURL, lib.FieldTypeTel:
+		return true
+	}
+	return false
+}
+
+// formatFloat formats a number the way HTML attributes expect, without exponents
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -10,3 +10,19 @@ import (
 func ConvertFormToHtml(ctx context.Context, form *lib.Form, w io.Writer) error {
 	return Form(form).Render(ctx, w)
 }
+
+// ConvertFormWithValuesToHtml renders a form populated with submitted values and the
+// errors found validating them, so a failed submission can be shown again with the
+// user's input preserved and error messages next to the offending fields
+func ConvertFormWithValuesToHtml(ctx context.Context, form *lib.Form, values map[string]any, errs lib.FieldErrors, w io.Writer) error {
+	return ConvertFormToHtml(ctx, form.Populate(values, errs), w)
+}
+
+// ConvertFormToHtmlPage renders a form as a standalone HTML document styled by theme,
+// for forms that are served or saved on their own rather than embedded in a page
+func ConvertFormToHtmlPage(ctx context.Context, form *lib.Form, theme Theme, w io.Writer) error {
+	if err := ValidateTheme(theme); err != nil {
+		return err
+	}
+	return Page(form, theme).Render(ctx, w)
+}
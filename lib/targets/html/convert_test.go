@@ -56,6 +56,53 @@ func TestConvertFormToHtml(t *testing.T) {
 				"This is a test form",
 				"</form>",
 			},
+			notContains: []string{"data-action", "<script>"},
+		},
+		{
+			name: "single field without a name",
+			form: &lib.Form{
+				Fields: []lib.Field{{Type: lib.FieldTypeText, Label: "Comment"}},
+			},
+			wantContains: []string{
+				`<div class="fields"><div class="field"><label for="">Comment</label> <input type="text" id="" name="" value=""></div></div>`,
+			},
+		},
+		{
+			name: "method HTML forms cannot submit with",
+			form: &lib.Form{
+				Method: "patch",
+				Action: "/pets/{id}",
+				Fields: []lib.Field{{Name: "id", Type: lib.FieldTypeNumber}},
+			},
+			wantContains: []string{
+				`<form method="POST" action="/pets/{id}" class="form" data-action="/pets/{id}"><input type="hidden" name="_method" value="PATCH">`,
+			},
+		},
+		{
+			name: "path template without a value filled in on submit",
+			form: &lib.Form{
+				Method: "POST",
+				Action: "/pets/{id}",
+				Fields: []lib.Field{{Name: "id", Type: lib.FieldTypeNumber}},
+			},
+			wantContains: []string{
+				`<form method="POST" action="/pets/{id}" class="form" data-action="/pets/{id}">`,
+				`form.addEventListener("submit"`,
+				`form.dataset.action.replace(`,
+			},
+		},
+		{
+			name: "path templates replaced by field values",
+			form: &lib.Form{
+				Method: "GET",
+				Action: "/owners/{owner}/pets/{id}",
+				Fields: []lib.Field{
+					{Name: "owner", Type: lib.FieldTypeText, Default: "ada lovelace"},
+					{Name: "id", Type: lib.FieldTypeNumber},
+				},
+			},
+			wantContains: []string{`<form method="GET" action="/owners/ada%20lovelace/pets/{id}" class="form" data-action="/owners/{owner}/pets/{id}">`},
+			notContains:  []string{"_method"},
 		},
 		{
 			name: "form with text field",
@@ -545,6 +592,1087 @@ func TestConvertFormToHtml_EmptyFields(t *testing.T) {
 	}
 }
 
+func TestConvertFormToHtml_ValidationAttributes(t *testing.T) {
+	tests := []struct {
+		name         string
+		field        lib.Field
+		wantContains []string
+		notContains  []string
+	}{
+		{
+			name: "text field with length and pattern",
+			field: lib.Field{
+				Name: "username",
+				Type: lib.FieldTypeText,
+				Validation: &lib.Validation{
+					Required:     true,
+					MinLength:    intPtr(3),
+					MaxLength:    intPtr(20),
+					Pattern:      "^[a-z]+$",
+					PatternError: "Lowercase letters only",
+				},
+			},
+			wantContains: []string{
+				" required",
+				`minlength="3"`,
+				`maxlength="20"`,
+				`pattern="^[a-z]+$"`,
+				`title="Lowercase letters only"`,
+				`aria-describedby="username-pattern-error"`,
+				`<span id="username-pattern-error" class="pattern-error" hidden>Lowercase letters only</span>`,
+			},
+		},
+		{
+			name: "pattern without error message",
+			field: lib.Field{
+				Name:       "code",
+				Type:       lib.FieldTypeText,
+				Validation: &lib.Validation{Pattern: "[0-9]+"},
+			},
+			wantContains: []string{`pattern=".*(?:[0-9]+).*"`},
+			notContains:  []string{"title=", "aria-describedby", "pattern-error"},
+		},
+		{
+			name: "anchored alternatives match anywhere",
+			field: lib.Field{
+				Name:       "code",
+				Type:       lib.FieldTypeText,
+				Validation: &lib.Validation{Pattern: "^a|b$"},
+			},
+			wantContains: []string{`pattern=".*(?:^a|b$).*"`},
+		},
+		{
+			name: "anchored pattern with groups and classes",
+			field: lib.Field{
+				Name:       "slug",
+				Type:       lib.FieldTypeText,
+				Validation: &lib.Validation{Pattern: `^(?:[a-z0-9]|_)+(-[a-z]{2,3})?$`},
+			},
+			wantContains: []string{`pattern="^(?:[a-z0-9]|_)+(-[a-z]{2,3})?$"`},
+		},
+		{
+			name: "pattern invalid with the v flag is left out",
+			field: lib.Field{
+				Name:       "handle",
+				Type:       lib.FieldTypeText,
+				Validation: &lib.Validation{Pattern: "^[a-zA-Z0-9_-]+$", PatternError: "Letters, digits, _ and - only"},
+			},
+			notContains: []string{"pattern=", "title=", "aria-describedby", "pattern-error"},
+		},
+		{
+			name: "email field",
+			field: lib.Field{
+				Name:       "email",
+				Type:       lib.FieldTypeEmail,
+				Validation: &lib.Validation{Required: true, MaxLength: intPtr(254)},
+			},
+			wantContains: []string{`type="email"`, " required", `maxlength="254"`},
+		},
+		{
+			name: "password field",
+			field: lib.Field{
+				Name:       "password",
+				Type:       lib.FieldTypePassword,
+				Validation: &lib.Validation{MinLength: intPtr(8)},
+			},
+			wantContains: []string{`type="password"`, `minlength="8"`},
+		},
+		{
+			name: "url field",
+			field: lib.Field{
+				Name:       "website",
+				Type:       lib.FieldTypeURL,
+				Validation: &lib.Validation{Pattern: "^https://.*$", PatternError: "Must use https"},
+			},
+			wantContains: []string{`type="url"`, `pattern="^https://.*$"`, `title="Must use https"`},
+		},
+		{
+			name: "number field with range and step",
+			field: lib.Field{
+				Name: "age",
+				Type: lib.FieldTypeNumber,
+				Validation: &lib.Validation{
+					Required: true,
+					Min:      floatPtr(18),
+					Max:      floatPtr(120),
+					Step:     floatPtr(0.5),
+				},
+			},
+			wantContains: []string{`type="number"`, " required", `min="18"`, `max="120"`, `step="0.5"`},
+		},
+		{
+			name:         "number field without a step accepts fractions",
+			field:        lib.Field{Name: "price", Type: lib.FieldTypeNumber, Validation: &lib.Validation{Min: floatPtr(0)}},
+			wantContains: []string{`step="any"`},
+		},
+		{
+			name:        "integer field steps by whole numbers",
+			field:       lib.Field{Name: "count", Type: lib.FieldTypeNumber, DataType: lib.DataTypeInteger},
+			notContains: []string{"step="},
+		},
+		{
+			name: "min rounded up to a multiple of the step",
+			field: lib.Field{
+				Name:       "count",
+				Type:       lib.FieldTypeNumber,
+				DataType:   lib.DataTypeInteger,
+				Validation: &lib.Validation{Min: floatPtr(3), Step: floatPtr(5)},
+			},
+			wantContains: []string{`min="5"`, `step="5"`},
+		},
+		{
+			name: "min rounded up to a fractional step",
+			field: lib.Field{
+				Name:       "ratio",
+				Type:       lib.FieldTypeNumber,
+				Validation: &lib.Validation{Min: floatPtr(0.25), Max: floatPtr(0.9), Step: floatPtr(0.1)},
+			},
+			wantContains: []string{`min="0.3"`, `max="0.9"`, `step="0.1"`},
+		},
+		{
+			name: "exclusive min rounded up to a multiple of the step",
+			field: lib.Field{
+				Name:       "count",
+				Type:       lib.FieldTypeNumber,
+				DataType:   lib.DataTypeInteger,
+				Validation: &lib.Validation{Min: floatPtr(0), ExclusiveMin: true, Step: floatPtr(5)},
+			},
+			wantContains: []string{`min="5"`, `step="5"`},
+		},
+		{
+			name: "exclusive bounds of an integer",
+			field: lib.Field{
+				Name:       "count",
+				Type:       lib.FieldTypeNumber,
+				DataType:   lib.DataTypeInteger,
+				Validation: &lib.Validation{Min: floatPtr(0), ExclusiveMin: true, Max: floatPtr(10.5), ExclusiveMax: true},
+			},
+			wantContains: []string{`min="1"`, `max="10"`},
+		},
+		{
+			name: "exclusive bounds of a number",
+			field: lib.Field{
+				Name:       "ratio",
+				Type:       lib.FieldTypeNumber,
+				Validation: &lib.Validation{Min: floatPtr(0), ExclusiveMin: true, Max: floatPtr(1), ExclusiveMax: true},
+			},
+			wantContains: []string{`min="0"`, `max="1"`},
+		},
+		{
+			name: "large numbers are not formatted with exponents",
+			field: lib.Field{
+				Name:       "amount",
+				Type:       lib.FieldTypeNumber,
+				Validation: &lib.Validation{Max: floatPtr(10000000)},
+			},
+			wantContains: []string{`max="10000000"`},
+		},
+		{
+			name: "textarea field",
+			field: lib.Field{
+				Name:       "bio",
+				Type:       lib.FieldTypeTextarea,
+				Validation: &lib.Validation{Required: true, MaxLength: intPtr(500), Pattern: ".*"},
+			},
+			wantContains: []string{"<textarea", " required", `maxlength="500"`},
+			notContains:  []string{"pattern="},
+		},
+		{
+			name: "date field",
+			field: lib.Field{
+				Name:       "birthday",
+				Type:       lib.FieldTypeDate,
+				Validation: &lib.Validation{Required: true},
+			},
+			wantContains: []string{`type="date"`, " required"},
+		},
+		{
+			name: "time field",
+			field: lib.Field{
+				Name:       "start",
+				Type:       lib.FieldTypeTime,
+				Validation: &lib.Validation{Required: true},
+			},
+			wantContains: []string{`type="time"`, " required"},
+		},
+		{
+			name: "datetime field",
+			field: lib.Field{
+				Name:       "appointment",
+				Type:       lib.FieldTypeDateTime,
+				Validation: &lib.Validation{Required: true},
+			},
+			wantContains: []string{`type="datetime-local"`, " required"},
+		},
+		{
+			name: "select field",
+			field: lib.Field{
+				Name:       "country",
+				Type:       lib.FieldTypeSelect,
+				Options:    []lib.Option{{Label: "USA", Value: "us"}},
+				Validation: &lib.Validation{Required: true},
+			},
+			wantContains: []string{"<select", " required"},
+		},
+		{
+			name: "radio field",
+			field: lib.Field{
+				Name:       "size",
+				Type:       lib.FieldTypeRadio,
+				Options:    []lib.Option{{Label: "Small", Value: "s"}},
+				Validation: &lib.Validation{Required: true},
+			},
+			wantContains: []string{`type="radio"`, " required"},
+		},
+		{
+			name: "boolean checkbox is never required",
+			field: lib.Field{
+				Name:       "subscribe",
+				Type:       lib.FieldTypeCheckbox,
+				Validation: &lib.Validation{Required: true},
+			},
+			wantContains: []string{`type="checkbox"`},
+			notContains:  []string{" required"},
+		},
+		{
+			name: "file field",
+			field: lib.Field{
+				Name:       "avatar",
+				Type:       lib.FieldTypeFile,
+				Validation: &lib.Validation{Required: true},
+			},
+			wantContains: []string{`type="file"`, " required"},
+		},
+		{
+			name: "hidden field ignores validation",
+			field: lib.Field{
+				Name:       "token",
+				Type:       lib.FieldTypeHidden,
+				Validation: &lib.Validation{Required: true},
+			},
+			wantContains: []string{`type="hidden"`},
+			notContains:  []string{" required"},
+		},
+		{
+			name: "length rules are not emitted on number fields",
+			field: lib.Field{
+				Name:       "count",
+				Type:       lib.FieldTypeNumber,
+				Validation: &lib.Validation{MinLength: intPtr(1), Pattern: "[0-9]+"},
+			},
+			notContains: []string{"minlength", "pattern="},
+		},
+		{
+			name: "range rules are not emitted on text fields",
+			field: lib.Field{
+				Name:       "name",
+				Type:       lib.FieldTypeText,
+				Validation: &lib.Validation{Min: floatPtr(1), Max: floatPtr(2), Step: floatPtr(1)},
+			},
+			notContains: []string{"min=", "max=", "step="},
+		},
+		{
+			name: "field without validation",
+			field: lib.Field{
+				Name: "plain",
+				Type: lib.FieldTypeText,
+			},
+			notContains: []string{" required", "minlength", "maxlength", "pattern="},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &lib.Form{Fields: []lib.Field{tt.field}}
+
+			var buf bytes.Buffer
+			if err := ConvertFormToHtml(context.Background(), form, &buf); err != nil {
+				t.Fatalf("ConvertFormToHtml() error = %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("ConvertFormToHtml() output does not contain %q. Output: %s", want, output)
+				}
+			}
+			for _, notWant := range tt.notContains {
+				if strings.Contains(output, notWant) {
+					t.Errorf("ConvertFormToHtml() output contains unexpected %q. Output: %s", notWant, output)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertFormToHtml_OptionGroups(t *testing.T) {
+	tests := []struct {
+		name         string
+		field        lib.Field
+		wantContains []string
+		notContains  []string
+	}{
+		{
+			name: "radio group renders one input per option",
+			field: lib.Field{
+				Name:  "size",
+				Type:  lib.FieldTypeRadio,
+				Label: "Size",
+				Options: []lib.Option{
+					{Label: "Small", Value: "s"},
+					{Label: "Medium", Value: "m"},
+					{Label: "Large", Value: "l"},
+				},
+			},
+			wantContains: []string{
+				`<fieldset class="radio-group"><legend>Size</legend>`,
+				`<label for="size-0"><input type="radio" id="size-0" name="size" value="s"> Small</label>`,
+				`<label for="size-1"><input type="radio" id="size-1" name="size" value="m"> Medium</label>`,
+				`<label for="size-2"><input type="radio" id="size-2" name="size" value="l"> Large</label>`,
+			},
+			notContains: []string{"checked"},
+		},
+		{
+			name: "radio group checked from value",
+			field: lib.Field{
+				Name:    "size",
+				Type:    lib.FieldTypeRadio,
+				Value:   "m",
+				Default: "s",
+				Options: []lib.Option{
+					{Label: "Small", Value: "s"},
+					{Label: "Medium", Value: "m"},
+				},
+			},
+			wantContains: []string{`value="m" checked>`, `value="s">`},
+		},
+		{
+			name: "radio group checked from default",
+			field: lib.Field{
+				Name:    "size",
+				Type:    lib.FieldTypeRadio,
+				Default: "s",
+				Options: []lib.Option{
+					{Label: "Small", Value: "s"},
+					{Label: "Medium", Value: "m"},
+				},
+			},
+			wantContains: []string{`value="s" checked>`, `value="m">`},
+		},
+		{
+			name: "radio group with numeric values",
+			field: lib.Field{
+				Name:    "rating",
+				Type:    lib.FieldTypeRadio,
+				Default: 2,
+				Options: []lib.Option{
+					{Label: "1", Value: 1},
+					{Label: "2", Value: 2},
+				},
+			},
+			wantContains: []string{`value="2" checked>`},
+		},
+		{
+			name: "required radio group",
+			field: lib.Field{
+				Name:       "size",
+				Type:       lib.FieldTypeRadio,
+				Options:    []lib.Option{{Label: "Small", Value: "s"}},
+				Validation: &lib.Validation{Required: true},
+			},
+			wantContains: []string{`value="s" required>`},
+		},
+		{
+			name: "checkbox group checks every selected value",
+			field: lib.Field{
+				Name:  "tags",
+				Type:  lib.FieldTypeCheckbox,
+				Label: "Tags",
+				Value: []any{"go", "html"},
+				Options: []lib.Option{
+					{Label: "Go", Value: "go"},
+					{Label: "Rust", Value: "rust"},
+					{Label: "HTML", Value: "html"},
+				},
+			},
+			wantContains: []string{
+				`<fieldset class="checkbox-group"><legend>Tags</legend>`,
+				`<input type="checkbox" id="tags-0" name="tags" value="go" checked> Go`,
+				`<input type="checkbox" id="tags-1" name="tags" value="rust"> Rust`,
+				`<input type="checkbox" id="tags-2" name="tags" value="html" checked> HTML`,
+			},
+		},
+		{
+			name: "checkbox group with typed slice default",
+			field: lib.Field{
+				Name:    "days",
+				Type:    lib.FieldTypeCheckbox,
+				Default: []string{"sat"},
+				Options: []lib.Option{
+					{Label: "Saturday", Value: "sat"},
+					{Label: "Sunday", Value: "sun"},
+				},
+			},
+			wantContains: []string{`value="sat" checked>`, `value="sun">`},
+		},
+		{
+			name: "boolean checkbox is not a group",
+			field: lib.Field{
+				Name: "subscribe",
+				Type: lib.FieldTypeCheckbox,
+			},
+			notContains: []string{"<fieldset"},
+		},
+		{
+			name: "select marks selected option",
+			field: lib.Field{
+				Name:  "country",
+				Type:  lib.FieldTypeSelect,
+				Value: "ca",
+				Options: []lib.Option{
+					{Label: "USA", Value: "us"},
+					{Label: "Canada", Value: "ca"},
+				},
+			},
+			wantContains: []string{`<option value="us">USA</option>`, `<option value="ca" selected>Canada</option>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &lib.Form{Fields: []lib.Field{tt.field}}
+
+			var buf bytes.Buffer
+			if err := ConvertFormToHtml(context.Background(), form, &buf); err != nil {
+				t.Fatalf("ConvertFormToHtml() error = %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("ConvertFormToHtml() output does not contain %q. Output: %s", want, output)
+				}
+			}
+			for _, notWant := range tt.notContains {
+				if strings.Contains(output, notWant) {
+					t.Errorf("ConvertFormToHtml() output contains unexpected %q. Output: %s", notWant, output)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertFormToHtml_Variants(t *testing.T) {
+	payment := lib.Field{
+		Name:          "payment",
+		Type:          lib.FieldTypeVariant,
+		Label:         "Payment method",
+		Discriminator: "method",
+		Variants: []lib.Variant{
+			{Label: "Card", Value: "card", Fields: []lib.Field{{Name: "number", Type: lib.FieldTypeText, Label: "Card number"}}},
+			{Label: "Bank transfer", Value: "bank", Fields: []lib.Field{{Name: "iban", Type: lib.FieldTypeText, Label: "IBAN"}}},
+		},
+	}
+	selected := payment
+	selected.Value = map[string]any{"method": "bank", "iban": "SE35"}
+	marked := payment
+	marked.Variants = []lib.Variant{payment.Variants[0], payment.Variants[1]}
+	marked.Variants[1].Selected = true
+
+	tests := []struct {
+		name         string
+		field        lib.Field
+		wantContains []string
+		notContains  []string
+	}{
+		{
+			name:  "selector and variant fieldsets",
+			field: payment,
+			wantContains: []string{
+				`<fieldset class="variant"><legend>Payment method</legend>`,
+				`<select id="payment" name="payment" data-variant-selector>`,
+				`<option value="card" selected>Card</option><option value="bank">Bank transfer</option>`,
+				`<fieldset class="variant-fields" data-variant="card">`,
+				`<fieldset class="variant-fields" data-variant="bank" hidden disabled>`,
+				`<label for="payment-variant-1-iban">IBAN</label> <input type="text" id="payment-variant-1-iban" name="payment.iban" value="">`,
+				`data-variant-selector`,
+				`<script>`,
+			},
+		},
+		{
+			name:  "variant selected from value",
+			field: selected,
+			wantContains: []string{
+				`<option value="card">Card</option><option value="bank" selected>Bank transfer</option>`,
+				`<fieldset class="variant-fields" data-variant="card" hidden disabled>`,
+				`<fieldset class="variant-fields" data-variant="bank">`,
+			},
+		},
+		{
+			name:  "variant marked selected",
+			field: marked,
+			wantContains: []string{
+				`<option value="card">Card</option><option value="bank" selected>Bank transfer</option>`,
+				`<fieldset class="variant-fields" data-variant="bank">`,
+			},
+		},
+		{
+			name:        "no script without variants",
+			field:       lib.Field{Name: "username", Type: lib.FieldTypeText},
+			notContains: []string{"<script>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &lib.Form{Fields: []lib.Field{tt.field}}
+
+			var buf bytes.Buffer
+			if err := ConvertFormToHtml(context.Background(), form, &buf); err != nil {
+				t.Fatalf("ConvertFormToHtml() error = %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("ConvertFormToHtml() output does not contain %q. Output: %s", want, output)
+				}
+			}
+			for _, notWant := range tt.notContains {
+				if strings.Contains(output, notWant) {
+					t.Errorf("ConvertFormToHtml() output contains unexpected %q. Output: %s", notWant, output)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertFormToHtml_Naming(t *testing.T) {
+	fields := []lib.Field{
+		{Name: "billing", Type: lib.FieldTypeObject, Fields: []lib.Field{{Name: "street", Type: lib.FieldTypeText, Label: "Street"}}},
+		{Name: "shipping", Type: lib.FieldTypeObject, Fields: []lib.Field{{Name: "street", Type: lib.FieldTypeText, Label: "Street"}}},
+		{Name: "tags", Type: lib.FieldTypeArray, Fields: []lib.Field{{Name: "item", Type: lib.FieldTypeText}}},
+		{Name: "lines", Type: lib.FieldTypeArray, Fields: []lib.Field{{Name: "item", Type: lib.FieldTypeObject, Fields: []lib.Field{
+			{Name: "qty", Type: lib.FieldTypeNumber},
+		}}}},
+	}
+
+	tests := []struct {
+		name         string
+		naming       lib.NamingScheme
+		wantContains []string
+	}{
+		{
+			name: "dotted by default",
+			wantContains: []string{
+				`<label for="billing-street">Street</label> <input type="text" id="billing-street" name="billing.street"`,
+				`<label for="shipping-street">Street</label> <input type="text" id="shipping-street" name="shipping.street"`,
+				`<input type="text" id="tags-0" name="tags[0]"`,
+				`<input type="number" id="lines-0-qty" name="lines[0].qty"`,
+			},
+		},
+		{
+			name:   "bracket",
+			naming: lib.NamingBracket,
+			wantContains: []string{
+				`<input type="text" id="billing-street" name="billing[street]"`,
+				`<input type="text" id="shipping-street" name="shipping[street]"`,
+				`<input type="text" id="tags-0" name="tags[0]"`,
+				`<input type="number" id="lines-0-qty" name="lines[0][qty]"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &lib.Form{Naming: tt.naming, Fields: fields}
+
+			var buf bytes.Buffer
+			if err := ConvertFormToHtml(context.Background(), form, &buf); err != nil {
+				t.Fatalf("ConvertFormToHtml() error = %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("ConvertFormToHtml() output does not contain %q. Output: %s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertFormToHtml_Arrays(t *testing.T) {
+	one, two := 1, 2
+	tags := func(validation *lib.Validation, value any) lib.Field {
+		return lib.Field{
+			Name:       "tags",
+			Type:       lib.FieldTypeArray,
+			Value:      value,
+			Validation: validation,
+			Fields:     []lib.Field{{Name: "item", Type: lib.FieldTypeText}},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		field        lib.Field
+		wantContains []string
+		notContains  []string
+	}{
+		{
+			name:  "items from value",
+			field: tags(nil, []any{"go", "templ"}),
+			wantContains: []string{
+				`<div class="array" data-array="tags" data-array-id="tags">`,
+				`<li class="array-item" data-index="0"><div class="field"><label for="tags-0"></label> <input type="text" id="tags-0" name="tags[0]" value="go">`,
+				`<input type="text" id="tags-1" name="tags[1]" value="templ">`,
+				`<button type="submit" name="_remove-item" value="tags[1]" formnovalidate data-array-remove>Remove</button>`,
+				`<button type="button" data-array-move="up" hidden>Move up</button>`,
+				`<button type="submit" class="array-add" name="_add-item" value="tags" formnovalidate data-array-add>Add item</button>`,
+				`<script>`,
+			},
+			notContains: []string{`name="tags[2]"`},
+		},
+		{
+			name:  "item template",
+			field: tags(nil, nil),
+			wantContains: []string{
+				`<template><li class="array-item" data-index="__index__">`,
+				`<input type="text" id="tags-__index__" name="tags[__index__]" value="">`,
+			},
+		},
+		{
+			name:  "one empty item without value",
+			field: tags(nil, nil),
+			wantContains: []string{
+				`<input type="text" id="tags-0" name="tags[0]" value="">`,
+			},
+			notContains: []string{`name="tags[1]"`},
+		},
+		{
+			name:  "padded to min items and remove disabled",
+			field: tags(&lib.Validation{MinItems: &two}, []any{"go"}),
+			wantContains: []string{
+				`data-min-items="2"`,
+				`<input type="text" id="tags-1" name="tags[1]" value="">`,
+				`value="tags[1]" formnovalidate data-array-remove disabled>`,
+			},
+		},
+		{
+			name:  "add disabled at max items",
+			field: tags(&lib.Validation{MaxItems: &one}, []any{"go"}),
+			wantContains: []string{
+				`data-max-items="1"`,
+				`value="tags" formnovalidate data-array-add disabled>`,
+			},
+		},
+		{
+			name: "nested array in object items",
+			field: lib.Field{
+				Name: "lines",
+				Type: lib.FieldTypeArray,
+				Value: []any{
+					map[string]any{"sku": "a"},
+				},
+				Fields: []lib.Field{{Name: "item", Type: lib.FieldTypeObject, Fields: []lib.Field{
+					{Name: "sku", Type: lib.FieldTypeText},
+					{Name: "notes", Type: lib.FieldTypeArray, Fields: []lib.Field{{Name: "item", Type: lib.FieldTypeText}}},
+				}}},
+			},
+			wantContains: []string{
+				`<input type="text" id="lines-0-sku" name="lines[0].sku" value="a">`,
+				`data-array="lines[0].notes" data-array-id="lines-0-notes"`,
+				`<input type="text" id="lines-0-notes-0" name="lines[0].notes[0]" value="">`,
+				`data-array="lines[__index__].notes"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &lib.Form{Fields: []lib.Field{tt.field}}
+
+			var buf bytes.Buffer
+			if err := ConvertFormToHtml(context.Background(), form, &buf); err != nil {
+				t.Fatalf("ConvertFormToHtml() error = %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("ConvertFormToHtml() output does not contain %q. Output: %s", want, output)
+				}
+			}
+			for _, notWant := range tt.notContains {
+				if strings.Contains(output, notWant) {
+					t.Errorf("ConvertFormToHtml() output contains unexpected %q. Output: %s", notWant, output)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertFormToHtml_Conditionals(t *testing.T) {
+	country := func(value any) lib.Field {
+		return lib.Field{
+			Name:  "country",
+			Type:  lib.FieldTypeText,
+			Value: value,
+			Conditional: &lib.ConditionalField{
+				Condition: "country",
+				Value:     "US",
+				Then:      []lib.Field{{Name: "state", Type: lib.FieldTypeText, Label: "State"}},
+				Else:      []lib.Field{{Name: "region", Type: lib.FieldTypeText, Label: "Region"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		fields       []lib.Field
+		wantContains []string
+		notContains  []string
+	}{
+		{
+			name:   "else branch applies without a value",
+			fields: []lib.Field{country(nil)},
+			wantContains: []string{
+				`<fieldset class="conditional" data-condition="{&#34;operator&#34;:&#34;equals&#34;,&#34;field&#34;:&#34;country&#34;,&#34;value&#34;:&#34;US&#34;}" data-branch="then" hidden disabled><div class="field"><label for="state">State</label>`,
+				`<fieldset class="conditional" data-condition="{&#34;operator&#34;:&#34;equals&#34;,&#34;field&#34;:&#34;country&#34;,&#34;value&#34;:&#34;US&#34;}" data-branch="else"><div class="field"><label for="region">Region</label>`,
+				`<script>`,
+			},
+		},
+		{
+			name:   "then branch applies when the condition is met",
+			fields: []lib.Field{country("US")},
+			wantContains: []string{
+				`data-branch="then"><div class="field"><label for="state">`,
+				`data-branch="else" hidden disabled>`,
+			},
+		},
+		{
+			name: "presence condition in nested object",
+			fields: []lib.Field{{
+				Name: "company",
+				Type: lib.FieldTypeObject,
+				Fields: []lib.Field{
+					{Name: "has_vat", Type: lib.FieldTypeCheckbox, Value: true, Conditional: &lib.ConditionalField{
+						Condition: "has_vat",
+						Then:      []lib.Field{{Name: "vat", Type: lib.FieldTypeText}},
+					}},
+				},
+			}},
+			wantContains: []string{
+				`&#34;field&#34;:&#34;company.has_vat&#34;`,
+				`data-branch="then"><div class="field"><label for="company-vat"></label> <input type="text" id="company-vat" name="company.vat"`,
+			},
+			notContains: []string{`data-branch="else"`, `&#34;field&#34;:&#34;has_vat&#34;`},
+		},
+		{
+			name: "compound condition",
+			fields: []lib.Field{
+				{Name: "age", Type: lib.FieldTypeNumber, Value: int64(20)},
+				{Name: "country", Type: lib.FieldTypeText, Value: "SE", Conditional: &lib.ConditionalField{
+					When: &lib.Condition{Operator: lib.ConditionAnd, Conditions: []lib.Condition{
+						{Operator: lib.ConditionRange, Field: "age", Min: floatPtr(18)},
+						{Operator: lib.ConditionIn, Field: "country", Values: []any{"SE", "NO"}},
+					}},
+					Then: []lib.Field{{Name: "license", Type: lib.FieldTypeText}},
+				}},
+			},
+			wantContains: []string{
+				`data-condition="{&#34;operator&#34;:&#34;and&#34;,&#34;conditions&#34;:[{&#34;operator&#34;:&#34;range&#34;,&#34;field&#34;:&#34;age&#34;,&#34;min&#34;:18},{&#34;operator&#34;:&#34;in&#34;,&#34;field&#34;:&#34;country&#34;,&#34;values&#34;:[&#34;SE&#34;,&#34;NO&#34;]}]}" data-branch="then"><div`,
+			},
+		},
+		{
+			name: "required when condition holds",
+			fields: []lib.Field{{
+				Name: "payment",
+				Type: lib.FieldTypeObject,
+				Fields: []lib.Field{
+					{Name: "billing_address", Type: lib.FieldTypeText, Validation: &lib.Validation{
+						RequiredWhen: &lib.Condition{Operator: lib.ConditionPresent, Field: "credit_card"},
+					}},
+					{Name: "credit_card", Type: lib.FieldTypeText, Value: "4111"},
+				},
+			}},
+			wantContains: []string{
+				`name="payment.billing_address" value="" data-required-when="{&#34;operator&#34;:&#34;present&#34;,&#34;field&#34;:&#34;payment.credit_card&#34;}" required>`,
+				`control.required = met(JSON.parse(control.dataset.requiredWhen));`,
+			},
+		},
+		{
+			name: "not required when condition does not hold",
+			fields: []lib.Field{
+				{Name: "billing_address", Type: lib.FieldTypeText, Validation: &lib.Validation{
+					RequiredWhen: &lib.Condition{Operator: lib.ConditionPresent, Field: "credit_card"},
+				}},
+				{Name: "credit_card", Type: lib.FieldTypeText},
+			},
+			wantContains: []string{
+				`name="billing_address" value="" data-required-when="{&#34;operator&#34;:&#34;present&#34;,&#34;field&#34;:&#34;credit_card&#34;}">`,
+				`<script>`,
+			},
+		},
+		{
+			name: "further conditionals follow the first",
+			fields: []lib.Field{
+				func() lib.Field {
+					field := country("US")
+					field.Conditionals = []lib.ConditionalField{{
+						When: &lib.Condition{Operator: lib.ConditionPresent, Field: "country"},
+						Then: []lib.Field{{Name: "vat", Type: lib.FieldTypeText, Label: "VAT"}},
+					}}
+					return field
+				}(),
+			},
+			wantContains: []string{
+				`data-branch="else" hidden disabled><div class="field"><label for="region">Region</label> <input type="text" id="region" name="region" value=""></div></fieldset><fieldset class="conditional" data-condition="{&#34;operator&#34;:&#34;present&#34;,&#34;field&#34;:&#34;country&#34;}" data-branch="then"><div class="field"><label for="vat">`,
+			},
+		},
+		{
+			name:        "no script without conditionals",
+			fields:      []lib.Field{{Name: "username", Type: lib.FieldTypeText}},
+			notContains: []string{"<script>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &lib.Form{Fields: tt.fields}
+
+			var buf bytes.Buffer
+			if err := ConvertFormToHtml(context.Background(), form, &buf); err != nil {
+				t.Fatalf("ConvertFormToHtml() error = %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("ConvertFormToHtml() output does not contain %q. Output: %s", want, output)
+				}
+			}
+			for _, notWant := range tt.notContains {
+				if strings.Contains(output, notWant) {
+					t.Errorf("ConvertFormToHtml() output contains unexpected %q. Output: %s", notWant, output)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertFormWithValuesToHtml(t *testing.T) {
+	form := &lib.Form{
+		Fields: []lib.Field{
+			{Name: "username", Type: lib.FieldTypeText, Validation: &lib.Validation{Pattern: "[a-z]+", PatternError: "Lowercase only"}},
+			{Name: "age", Type: lib.FieldTypeNumber},
+			{Name: "bio", Type: lib.FieldTypeTextarea},
+			{Name: "subscribe", Type: lib.FieldTypeCheckbox},
+			{Name: "size", Type: lib.FieldTypeRadio, Options: []lib.Option{{Label: "S", Value: "s"}, {Label: "M", Value: "m"}}},
+			{Name: "country", Type: lib.FieldTypeSelect, Options: []lib.Option{{Label: "USA", Value: "us"}, {Label: "Sweden", Value: "se"}}},
+			{Name: "meeting", Type: lib.FieldTypeDateTime},
+			{Name: "start", Type: lib.FieldTypeTime},
+			{Name: "address", Type: lib.FieldTypeObject, Fields: []lib.Field{{Name: "zip", Type: lib.FieldTypeText}}},
+		},
+	}
+
+	values := map[string]any{
+		"username":  "Alice",
+		"age":       int64(42),
+		"bio":       "<b>hi</b>",
+		"subscribe": true,
+		"size":      "m",
+		"country":   "se",
+		"meeting":   "2024-05-01T13:30:00Z",
+		"start":     "09:15:30Z",
+		"address":   map[string]any{"zip": "abc"},
+	}
+	errs := lib.FieldErrors{
+		"":            "Please correct the errors below",
+		"username":    "Lowercase only",
+		"address.zip": "Invalid format",
+	}
+
+	var buf bytes.Buffer
+	if err := ConvertFormWithValuesToHtml(context.Background(), form, values, errs, &buf); err != nil {
+		t.Fatalf("ConvertFormWithValuesToHtml() error = %v", err)
+	}
+	output := buf.String()
+
+	wantContains := []string{
+		`<p class="form-error" role="alert">Please correct the errors below</p>`,
+		`<div class="field invalid">`,
+		`value="Alice"`,
+		`aria-describedby="username-pattern-error username-error"`,
+		`aria-invalid="true"`,
+		`<span id="username-error" class="field-error">Lowercase only</span>`,
+		`value="42"`,
+		`>&lt;b&gt;hi&lt;/b&gt;</textarea>`,
+		`<input type="checkbox" id="subscribe" name="subscribe" value="true" checked>`,
+		`value="m" checked`,
+		`<option value="se" selected>Sweden</option>`,
+		`value="2024-05-01T13:30"`,
+		`value="09:15:30"`,
+		`<input type="text" id="address-zip" name="address.zip" value="abc" aria-describedby="address-zip-error" aria-invalid="true">`,
+		`<span id="address-zip-error" class="field-error">Invalid format</span>`,
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("ConvertFormWithValuesToHtml() output does not contain %q. Output: %s", want, output)
+		}
+	}
+	if strings.Contains(output, `value="s" checked`) {
+		t.Errorf("ConvertFormWithValuesToHtml() output checks unselected radio option. Output: %s", output)
+	}
+}
+
+func TestConvertFormToHtml_FieldValues(t *testing.T) {
+	tests := []struct {
+		name         string
+		field        lib.Field
+		wantContains []string
+		notContains  []string
+	}{
+		{
+			name:         "unset value renders empty",
+			field:        lib.Field{Name: "name", Type: lib.FieldTypeText},
+			wantContains: []string{`value=""`},
+			notContains:  []string{"&lt;nil&gt;", "<nil>"},
+		},
+		{
+			name:         "default is used when value is unset",
+			field:        lib.Field{Name: "name", Type: lib.FieldTypeText, Default: "guest"},
+			wantContains: []string{`value="guest"`},
+		},
+		{
+			name:         "value takes precedence over default",
+			field:        lib.Field{Name: "name", Type: lib.FieldTypeText, Value: "alice", Default: "guest"},
+			wantContains: []string{`value="alice"`},
+		},
+		{
+			name:         "numbers are formatted without exponents",
+			field:        lib.Field{Name: "amount", Type: lib.FieldTypeNumber, Value: 25000000.0},
+			wantContains: []string{`value="25000000"`},
+		},
+		{
+			name:         "textarea value is its content",
+			field:        lib.Field{Name: "bio", Type: lib.FieldTypeTextarea, Default: "Hello"},
+			wantContains: []string{`>Hello</textarea>`},
+			notContains:  []string{`value=`},
+		},
+		{
+			name:         "checkbox checked from default",
+			field:        lib.Field{Name: "subscribe", Type: lib.FieldTypeCheckbox, Default: true},
+			wantContains: []string{`value="true" checked`},
+		},
+		{
+			name:         "checkbox unchecked by value",
+			field:        lib.Field{Name: "subscribe", Type: lib.FieldTypeCheckbox, Value: false, Default: true},
+			wantContains: []string{`value="true">`},
+			notContains:  []string{"checked"},
+		},
+		{
+			name:         "hidden const value",
+			field:        lib.Field{Name: "version", Type: lib.FieldTypeHidden, Value: 2},
+			wantContains: []string{`<input type="hidden" name="version" value="2">`},
+		},
+		{
+			name:        "file input has no value",
+			field:       lib.Field{Name: "avatar", Type: lib.FieldTypeFile, Value: "secret.txt"},
+			notContains: []string{"secret.txt"},
+		},
+		{
+			name:         "unparseable date-time is kept as is",
+			field:        lib.Field{Name: "at", Type: lib.FieldTypeDateTime, Value: "2024-05-01T13:30"},
+			wantContains: []string{`value="2024-05-01T13:30"`},
+		},
+		{
+			name:        "valid field is not marked invalid",
+			field:       lib.Field{Name: "name", Type: lib.FieldTypeText},
+			notContains: []string{"aria-invalid", "field-error", "invalid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &lib.Form{Fields: []lib.Field{tt.field}}
+
+			var buf bytes.Buffer
+			if err := ConvertFormToHtml(context.Background(), form, &buf); err != nil {
+				t.Fatalf("ConvertFormToHtml() error = %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("ConvertFormToHtml() output does not contain %q. Output: %s", want, output)
+				}
+			}
+			for _, notWant := range tt.notContains {
+				if strings.Contains(output, notWant) {
+					t.Errorf("ConvertFormToHtml() output contains unexpected %q. Output: %s", notWant, output)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertFormToHtmlPage(t *testing.T) {
+	form := &lib.Form{Title: "Signup", Fields: []lib.Field{{Name: "email", Type: lib.FieldTypeEmail}}}
+
+	tests := []struct {
+		name         string
+		theme        Theme
+		wantContains []string
+		notContains  []string
+		wantErr      bool
+	}{
+		{
+			name:  "default theme",
+			theme: ThemeDefault,
+			wantContains: []string{
+				`<!doctype html><html lang="en"><head><meta charset="utf-8">`,
+				`<title>Signup</title><style>`,
+				`<body><form method="" action="" class="form"><h1>Signup</h1>`,
+			},
+		},
+		{
+			name:         "no theme",
+			theme:        ThemeNone,
+			wantContains: []string{`<title>Signup</title></head><body><form`},
+			notContains:  []string{"<style>"},
+		},
+		{
+			name:    "unknown theme",
+			theme:   "dark",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := ConvertFormToHtmlPage(context.Background(), form, tt.theme, &buf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConvertFormToHtmlPage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			output := buf.String()
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("ConvertFormToHtmlPage() output does not contain %q. Output: %s", want, output)
+				}
+			}
+			for _, notWant := range tt.notContains {
+				if strings.Contains(output, notWant) {
+					t.Errorf("ConvertFormToHtmlPage() output contains unexpected %q. Output: %s", notWant, output)
+				}
+			}
+		})
+	}
+}
+
 // Helper functions
 func intPtr(i int) *int {
 	return &i
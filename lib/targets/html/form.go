@@ -0,0 +1,70 @@
+package html
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/a-h/templ"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+// pathTemplate matches a path template such as {id} in a form's action
+var pathTemplate = regexp.MustCompile(`\{[^{}]+\}`)
+
+// formMethod returns the method a form is submitted with. HTML forms can only be submitted
+// with GET and POST; forms with another method are submitted with POST and carry their
+// method in a hidden lib.MethodParam input.
+func formMethod(form *lib.Form) string {
+	if methodOverride(form) != "" {
+		return "POST"
+	}
+	return form.Method
+}
+
+// methodOverride returns the method a form submitted with POST stands in for, or ""
+func methodOverride(form *lib.Form) string {
+	switch method := strings.ToUpper(form.Method); method {
+	case "", "GET", "POST":
+		return ""
+	default:
+		return method
+	}
+}
+
+// formAction returns the action of a form with each path template such as {id} replaced
+// by the current value of the top-level field of that name, if it has one. Templates are
+// filled in again from the submitted values by the action script.
+func formAction(form *lib.Form) string {
+	if !strings.Contains(form.Action, "{") {
+		return form.Action
+	}
+
+	action := form.Action
+	for i := range form.Fields {
+		field := &form.Fields[i]
+		if field.Name == "" || !strings.Contains(action, "{"+field.Name+"}") {
+			continue
+		}
+		if value := inputValue(field); value != "" {
+			action = strings.ReplaceAll(action, "{"+field.Name+"}", url.PathEscape(value))
+		}
+	}
+	return action
+}
+
+// actionAttributes returns the data-action attribute of a form whose action has path
+// templates, holding the action for the action script to fill in from the values the
+// form is submitted with
+func actionAttributes(form *lib.Form) templ.Attributes {
+	if !hasPathTemplate(form) {
+		return nil
+	}
+	return templ.Attributes{"data-action": form.Action}
+}
+
+// hasPathTemplate reports whether a form's action has path templates
+func hasPathTemplate(form *lib.Form) bool {
+	return pathTemplate.MatchString(form.Action)
+}
@@ -36,9 +36,9 @@ func Form(form *lib.Form) templ.Component {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var2 string
-		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(form.Method)
+		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(formMethod(form))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `lib/targets/html/form.templ`, Line: 6, Col: 27}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `lib/targets/html/form.templ`, Line: 6, Col: 32}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
 		if templ_7745c5c3_Err != nil {
@@ -49,51 +49,269 @@ func Form(form *lib.Form) templ.Component {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var3 templ.SafeURL
-		templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinURLErrs(form.Action)
+		templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinURLErrs(formAction(form))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `lib/targets/html/form.templ`, Line: 6, Col: 50}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `lib/targets/html/form.templ`, Line: 6, Col: 60}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\" class=\"form\"><h1>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\" class=\"form\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var4 string
-		templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(form.Title)
+		templ_7745c5c3_Err = templ.RenderAttributes(ctx, templ_7745c5c3_Buffer, actionAttributes(form))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `lib/targets/html/form.templ`, Line: 7, Col: 18}
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, ">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if method := methodOverride(form); method != "" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "<input type=\"hidden\" name=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var4 string
+			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(lib.MethodParam)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `lib/targets/html/form.templ`, Line: 8, Col: 46}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var5 string
+			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(method)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `lib/targets/html/form.templ`, Line: 8, Col: 63}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "<h1>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+		var templ_7745c5c3_Var6 string
+		templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(form.Title)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `lib/targets/html/form.templ`, Line: 10, Col: 18}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</h1><p>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "</h1><p>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var5 string
-		templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(form.Description)
+		var templ_7745c5c3_Var7 string
+		templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(form.Description)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `lib/targets/html/form.templ`, Line: 8, Col: 23}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `lib/targets/html/form.templ`, Line: 11, Col: 23}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</p><div class=\"fields\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "</p>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		for _, field := range form.Fields {
-			templ_7745c5c3_Err = Field(&field).Render(ctx, templ_7745c5c3_Buffer)
+		if form.Error != "" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "<p class=\"form-error\" role=\"alert\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var8 string
+			templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(form.Error)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `lib/targets/html/form.templ`, Line: 13, Col: 50}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "</p>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</div><button type=\"submit\" class=\"submit-button\">Submit</button></form>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, "<div class=\"fields\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = Fields(form.Fields, rootName(form)).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, "</div><button type=\"submit\" class=\"submit-button\">Submit</button> ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if hasFieldType(form.Fields, lib.FieldTypeVariant) {
+			templ_7745c5c3_Err = variantScript().Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		if hasFieldType(form.Fields, lib.FieldTypeArray) {
+			templ_7745c5c3_Err = arrayScript().Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		if hasConditional(form.Fields) {
+			templ_7745c5c3_Err = conditionalScript().Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		if hasPathTemplate(form) {
+			templ_7745c5c3_Err = actionScript().Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, "</form>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+// actionScript fills in the path templates of the form's action, such as {id}, from the
+// values of the top-level fields of those names as the form is submitted. Templates
+// without a value are submitted as they are.
+func actionScript() templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var9 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var9 == nil {
+			templ_7745c5c3_Var9 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "<script>\n\t\t(function () {\n\t\t\tvar form = document.currentScript.closest(\"form\");\n\t\t\tform.addEventListener(\"submit\", function () {\n\t\t\t\tform.setAttribute(\"action\", form.dataset.action.replace(/\\{([^{}]+)\\}/g, function (template, name) {\n\t\t\t\t\tvar control = form.elements.namedItem(name);\n\t\t\t\t\treturn control && control.value !== \"\" ? encodeURIComponent(control.value) : template;\n\t\t\t\t}));\n\t\t\t});\n\t\t})();\n\t</script>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+// variantScript shows and enables the fields of the variant picked in each variant selector
+func variantScript() templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var10 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var10 == nil {
+			templ_7745c5c3_Var10 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "<script>\n\t\tdocument.currentScript.closest(\"form\").querySelectorAll(\"[data-variant-selector]\").forEach(function (selector) {\n\t\t\tselector.addEventListener(\"change\", function () {\n\t\t\t\tselector.parentElement.querySelectorAll(\":scope > [data-variant]\").forEach(function (fieldset) {\n\t\t\t\t\tvar selected = fieldset.dataset.variant === selector.value;\n\t\t\t\t\tfieldset.hidden = !selected;\n\t\t\t\t\tfieldset.disabled = !selected;\n\t\t\t\t});\n\t\t\t});\n\t\t});\n\t</script>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+// arrayScript adds, removes and moves array items in the browser instead of submitting the
+// form. Items are renumbered after every change so they are submitted in the order shown.
+func arrayScript() templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var11 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var11 == nil {
+			templ_7745c5c3_Var11 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "<script>\n\t\t(function () {\n\t\t\tvar form = document.currentScript.closest(\"form\");\n\t\t\tform.querySelectorAll(\"[data-array-move]\").forEach(function (button) {\n\t\t\t\tbutton.hidden = false;\n\t\t\t});\n\n\t\t\tform.addEventListener(\"click\", function (event) {\n\t\t\t\tvar button = event.target.closest(\"[data-array-add], [data-array-remove], [data-array-move]\");\n\t\t\t\tif (!button) {\n\t\t\t\t\treturn;\n\t\t\t\t}\n\t\t\t\tevent.preventDefault();\n\n\t\t\t\tvar array = button.closest(\"[data-array]\");\n\t\t\t\tvar list = array.querySelector(\":scope > .array-items\");\n\t\t\t\tvar row = button.closest(\".array-item\");\n\t\t\t\tif (button.hasAttribute(\"data-array-add\")) {\n\t\t\t\t\tlist.appendChild(array.querySelector(\":scope > template\").content.cloneNode(true));\n\t\t\t\t\tlist.lastElementChild.querySelectorAll(\"[data-array-move]\").forEach(function (move) {\n\t\t\t\t\t\tmove.hidden = false;\n\t\t\t\t\t});\n\t\t\t\t} else if (button.hasAttribute(\"data-array-remove\")) {\n\t\t\t\t\trow.remove();\n\t\t\t\t} else if (button.dataset.arrayMove === \"up\" && row.previousElementSibling) {\n\t\t\t\t\tlist.insertBefore(row, row.previousElementSibling);\n\t\t\t\t} else if (button.dataset.arrayMove === \"down\" && row.nextElementSibling) {\n\t\t\t\t\tlist.insertBefore(row.nextElementSibling, row);\n\t\t\t\t}\n\t\t\t\trenumber(array, list);\n\t\t\t});\n\n\t\t\tfunction renumber(array, list) {\n\t\t\t\tvar rows = list.querySelectorAll(\":scope > .array-item\");\n\t\t\t\trows.forEach(function (row, position) {\n\t\t\t\t\tvar index = row.dataset.index;\n\t\t\t\t\tif (index !== String(position)) {\n\t\t\t\t\t\trename(row, array.dataset.array + \"[\" + index + \"]\", array.dataset.array + \"[\" + position + \"]\", array.dataset.arrayId + \"-\" + index, array.dataset.arrayId + \"-\" + position);\n\t\t\t\t\t\trow.dataset.index = position;\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\tvar min = Number(array.dataset.minItems || 0);\n\t\t\t\tvar max = Number(array.dataset.maxItems || Infinity);\n\t\t\t\tarray.querySelector(\":scope > [data-array-add]\").disabled = rows.length >= max;\n\t\t\t\trows.forEach(function (row) {\n\t\t\t\t\trow.querySelector(\":scope > .array-item-controls > [data-array-remove]\").disabled = rows.length <= min;\n\t\t\t\t});\n\t\t\t}\n\n\t\t\tfunction rename(root, oldName, newName, oldID, newID) {\n\t\t\t\troot.querySelectorAll(\"*\").forEach(function (element) {\n\t\t\t\t\treplacePrefix(element, \"name\", oldName, newName, \".[\");\n\t\t\t\t\treplacePrefix(element, \"data-array\", oldName, newName, \".[\");\n\t\t\t\t\tif (element.matches(\"[data-array-add], [data-array-remove]\")) {\n\t\t\t\t\t\treplacePrefix(element, \"value\", oldName, newName, \".[\");\n\t\t\t\t\t}\n\t\t\t\t\t[\"id\", \"for\", \"data-array-id\", \"aria-describedby\"].forEach(function (attribute) {\n\t\t\t\t\t\treplacePrefix(element, attribute, oldID, newID, \"-\");\n\t\t\t\t\t});\n\t\t\t\t\tif (element.tagName === \"TEMPLATE\") {\n\t\t\t\t\t\trename(element.content, oldName, newName, oldID, newID);\n\t\t\t\t\t}\n\t\t\t\t});\n\t\t\t}\n\n\t\t\tfunction replacePrefix(element, attribute, from, to, separators) {\n\t\t\t\tif (!element.hasAttribute(attribute)) {\n\t\t\t\t\treturn;\n\t\t\t\t}\n\t\t\t\tvar values = element.getAttribute(attribute).split(\" \").map(function (value) {\n\t\t\t\t\tif (value === from || (value.startsWith(from) && separators.indexOf(value.charAt(from.length)) >= 0)) {\n\t\t\t\t\t\treturn to + value.slice(from.length);\n\t\t\t\t\t}\n\t\t\t\t\treturn value;\n\t\t\t\t});\n\t\t\t\telement.setAttribute(attribute, values.join(\" \"));\n\t\t\t}\n\t\t})();\n\t</script>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+// conditionalScript shows and enables the branch of each conditional whose rule applies,
+// and requires each field whose RequiredWhen condition holds, whenever the form is edited,
+// evaluating conditions the way lib's Condition.Met does
+func conditionalScript() templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var12 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var12 == nil {
+			templ_7745c5c3_Var12 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, "<script>\n\t\t(function () {\n\t\t\tvar form = document.currentScript.closest(\"form\");\n\n\t\t\tfunction values(name) {\n\t\t\t\tvar control = form.elements.namedItem(name);\n\t\t\t\tif (!control) {\n\t\t\t\t\treturn [];\n\t\t\t\t}\n\t\t\t\tif (!(control instanceof RadioNodeList) && control.type === \"checkbox\") {\n\t\t\t\t\treturn control.disabled ? [] : [String(control.checked)];\n\t\t\t\t}\n\t\t\t\tvar controls = control instanceof RadioNodeList ? Array.from(control) : [control];\n\t\t\t\treturn controls.filter(function (c) {\n\t\t\t\t\treturn !c.disabled && c.value !== \"\" && ((c.type !== \"checkbox\" && c.type !== \"radio\") || c.checked);\n\t\t\t\t}).map(function (c) {\n\t\t\t\t\treturn c.value;\n\t\t\t\t});\n\t\t\t}\n\n\t\t\tfunction met(condition) {\n\t\t\t\tvar submitted = condition.field ? values(condition.field) : [];\n\t\t\t\tswitch (condition.operator) {\n\t\t\t\tcase \"equals\":\n\t\t\t\t\treturn submitted.indexOf(String(condition.value)) >= 0;\n\t\t\t\tcase \"in\":\n\t\t\t\t\treturn condition.values.some(function (value) {\n\t\t\t\t\t\treturn submitted.indexOf(String(value)) >= 0;\n\t\t\t\t\t});\n\t\t\t\tcase \"range\":\n\t\t\t\t\treturn submitted.some(function (value) {\n\t\t\t\t\t\tvar number = Number(value);\n\t\t\t\t\t\tif (isNaN(number)) {\n\t\t\t\t\t\t\treturn false;\n\t\t\t\t\t\t}\n\t\t\t\t\t\tif (condition.min !== undefined && (number < condition.min || (condition.exclusiveMin && number === condition.min))) {\n\t\t\t\t\t\t\treturn false;\n\t\t\t\t\t\t}\n\t\t\t\t\t\treturn condition.max === undefined || !(number > condition.max || (condition.exclusiveMax && number === condition.max));\n\t\t\t\t\t});\n\t\t\t\tcase \"present\":\n\t\t\t\t\treturn submitted.length > 0;\n\t\t\t\tcase \"and\":\n\t\t\t\t\treturn condition.conditions.every(met);\n\t\t\t\tcase \"or\":\n\t\t\t\t\treturn condition.conditions.some(met);\n\t\t\t\tcase \"not\":\n\t\t\t\t\treturn !met(condition.conditions[0]);\n\t\t\t\t}\n\t\t\t\treturn false;\n\t\t\t}\n\n\t\t\tfunction update() {\n\t\t\t\tform.querySelectorAll(\"[data-condition]\").forEach(function (branch) {\n\t\t\t\t\tvar active = met(JSON.parse(branch.dataset.condition)) === (branch.dataset.branch === \"then\");\n\t\t\t\t\tbranch.hidden = !active;\n\t\t\t\t\tbranch.disabled = !active;\n\t\t\t\t});\n\t\t\t\tform.querySelectorAll(\"[data-required-when]\").forEach(function (control) {\n\t\t\t\t\tcontrol.required = met(JSON.parse(control.dataset.requiredWhen));\n\t\t\t\t});\n\t\t\t}\n\n\t\t\tform.addEventListener(\"input\", update);\n\t\t\tform.addEventListener(\"change\", update);\n\t\t\tupdate();\n\t\t})();\n\t</script>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -0,0 +1,72 @@
+package html
+
+import (
+	"strconv"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+// fieldName holds the qualified name a field is submitted under and the id of its input.
+// Ids follow the nesting of the form, so they stay unique where names may repeat, such
+// as fields of different variants.
+type fieldName struct {
+	naming lib.NamingScheme
+	Name   string
+	ID     string
+	index  string // Index of an array item, or itemPlaceholder for the item template
+}
+
+// itemPlaceholder stands in for the index of an array item rendered in an item template
+const itemPlaceholder = "__index__"
+
+// rootName returns the fieldName the top-level fields of the form are nested in
+func rootName(form *lib.Form) fieldName {
+	return fieldName{naming: form.Naming}
+}
+
+// child returns the fieldName of the field with the given name nested in n
+func (n fieldName) child(name string) fieldName {
+	return fieldName{
+		naming: n.naming,
+		Name:   n.naming.Child(n.Name, name),
+		ID:     joinID(n.ID, name),
+	}
+}
+
+// item returns the fieldName of the array item at index
+func (n fieldName) item(index int) fieldName {
+	return n.indexed(strconv.Itoa(index))
+}
+
+// itemTemplate returns the fieldName of an array item in the item template
+func (n fieldName) itemTemplate() fieldName {
+	return n.indexed(itemPlaceholder)
+}
+
+// indexed returns the fieldName of the array item with the given index
+func (n fieldName) indexed(index string) fieldName {
+	return fieldName{
+		naming: n.naming,
+		Name:   n.Name + "[" + index + "]",
+		ID:     joinID(n.ID, index),
+		index:  index,
+	}
+}
+
+// variant returns the fieldName the fields of the variant at index are nested in.
+// Variants share the name of their field but get ids of their own.
+func (n fieldName) variant(index int) fieldName {
+	return fieldName{
+		naming: n.naming,
+		Name:   n.Name,
+		ID:     joinID(n.ID, "variant-"+strconv.Itoa(index)),
+	}
+}
+
+// joinID appends a segment to an id
+func joinID(id, segment string) string {
+	if id == "" {
+		return segment
+	}
+	return id + "-" + segment
+}
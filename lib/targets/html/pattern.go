@@ -0,0 +1,180 @@
+package html
+
+import (
+	"strings"
+	"unicode"
+)
+
+// syntaxCharacters are the characters that may be escaped anywhere in a pattern
+const syntaxCharacters = `^$\.*+?()[]{}|/`
+
+// classSetPunctuators are the characters that may also be escaped in a character class
+// compiled with the v flag
+const classSetPunctuators = "&-!#%,:;<=>@`~"
+
+// escapeLetters are the letters and digits that start a character class or character escape
+const escapeLetters = "dDwWsSbBnrtfv0123456789cxukpP"
+
+// patternAttribute returns the value of the pattern attribute for a pattern, or false if it
+// cannot be used as one. Browsers match the pattern attribute against the whole value, so
+// a pattern that is not anchored at both ends is wrapped to match anywhere in the value, as
+// it does when validated on the server. Browsers compile the attribute with the v flag and
+// ignore it if that fails; such patterns are left to the server.
+func patternAttribute(pattern string) (string, bool) {
+	valid, alternation := scanPattern(pattern)
+	if !valid {
+		return "", false
+	}
+	if !alternation && strings.HasPrefix(pattern, "^") && endsWithAnchor(pattern) {
+		return pattern, true
+	}
+	return ".*(?:" + pattern + ").*", true
+}
+
+// endsWithAnchor reports whether a pattern ends with a $ that is not escaped
+func endsWithAnchor(pattern string) bool {
+	trimmed := strings.TrimSuffix(pattern, "$")
+	if trimmed == pattern {
+		return false
+	}
+	backslashes := len(trimmed) - len(strings.TrimRight(trimmed, `\`))
+	return backslashes%2 == 0
+}
+
+// scanPattern reports whether a pattern is valid with the v flag, as far as the syntax
+// shared with Go regular expressions goes, and whether it has a top-level alternation.
+// Character classes with the v flag may nest and combine with -- and &&, but may not hold
+// unescaped ( ) { } / | or hyphens outside of ranges, nor doubled punctuators.
+func scanPattern(pattern string) (bool, bool) {
+	runes := []rune(pattern)
+	alternation := false
+	groups, classes := 0, 0
+	atom := false // The previous rune of a class can start a range
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		var next rune
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		if c == '\\' {
+			if i+1 == len(runes) || !validEscape(next, classes > 0) {
+				return false, false
+			}
+			i++
+			atom = classes > 0
+			continue
+		}
+
+		if classes > 0 {
+			switch {
+			case c == '[':
+				classes++
+				if next == '^' {
+					i++
+				}
+				atom = false
+			case c == ']':
+				classes--
+				atom = false
+			case strings.ContainsRune("(){}/|", c):
+				return false, false
+			case c == '-' && next == '-', c == '&' && next == '&':
+				i++
+				atom = false
+			case c == '-':
+				if !atom || next == ']' || next == 0 {
+					return false, false
+				}
+				atom = false
+				i++ // The end of the range
+				if next == '\\' {
+					i++
+				}
+			case strings.ContainsRune("!#$%*+,.:;<=>?@^`~", c) && next == c:
+				return false, false
+			default:
+				atom = true
+			}
+			continue
+		}
+
+		switch c {
+		case '[':
+			classes++
+			if next == '^' {
+				i++
+			}
+			atom = false
+		case ']', '}':
+			return false, false
+		case '{':
+			end := quantifierEnd(runes, i)
+			if end < 0 {
+				return false, false
+			}
+			i = end
+		case '(':
+			if next == '?' && !validGroup(runes[i+2:]) {
+				return false, false
+			}
+			groups++
+		case ')':
+			if groups == 0 {
+				return false, false
+			}
+			groups--
+		case '|':
+			if groups == 0 {
+				alternation = true
+			}
+		}
+	}
+
+	return groups == 0 && classes == 0, alternation
+}
+
+// validEscape reports whether c may follow a backslash, in a character class or not
+func validEscape(c rune, inClass bool) bool {
+	if strings.ContainsRune(syntaxCharacters, c) || strings.ContainsRune(escapeLetters, c) {
+		return true
+	}
+	return inClass && strings.ContainsRune(classSetPunctuators, c)
+}
+
+// validGroup reports whether the text after "(?" starts a group JavaScript supports:
+// non-capturing, lookahead, lookbehind or named
+func validGroup(rest []rune) bool {
+	if len(rest) == 0 {
+		return false
+	}
+	switch rest[0] {
+	case ':', '=', '!':
+		return true
+	case '<':
+		return len(rest) > 1 && (rest[1] == '=' || rest[1] == '!' || unicode.IsLetter(rest[1]) || rest[1] == '_' || rest[1] == '$')
+	}
+	return false
+}
+
+// quantifierEnd returns the index of the } closing the {n}, {n,} or {n,m} quantifier
+// starting at i, or -1 if there is none
+func quantifierEnd(runes []rune, i int) int {
+	digits, comma := 0, false
+	for j := i + 1; j < len(runes); j++ {
+		switch {
+		case runes[j] >= '0' && runes[j] <= '9':
+			if !comma {
+				digits++
+			}
+		case runes[j] == ',' && !comma:
+			comma = true
+		case runes[j] == '}' && digits > 0:
+			return j
+		default:
+			return -1
+		}
+	}
+	return -1
+}
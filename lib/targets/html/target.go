@@ -0,0 +1,26 @@
+package html
+
+import (
+	"context"
+	"io"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+func init() {
+	lib.RegisterTarget("html", Target{}, "text/html")
+}
+
+// Target is the render target of HTML: a form fragment, or a standalone page styled by
+// Theme if it is set
+type Target struct {
+	Theme Theme
+}
+
+// Render implements lib.Target
+func (t Target) Render(ctx context.Context, form *lib.Form, w io.Writer) error {
+	if t.Theme == "" {
+		return ConvertFormToHtml(ctx, form, w)
+	}
+	return ConvertFormToHtmlPage(ctx, form, t.Theme, w)
+}
@@ -0,0 +1,22 @@
+package html
+
+import (
+	"fmt"
+)
+
+// Theme selects the stylesheet a form is rendered with as a standalone page
+type Theme string
+
+const (
+	ThemeNone    Theme = "none"    // No stylesheet, leaving the page to the browser's defaults
+	ThemeDefault Theme = "default" // A small stylesheet for the classes the HTML target emits
+)
+
+// ValidateTheme validates that the theme, if set, is a known theme
+func ValidateTheme(theme Theme) error {
+	switch theme {
+	case "", ThemeNone, ThemeDefault:
+		return nil
+	}
+	return fmt.Errorf("invalid theme '%s' (must be one of: %s, %s)", theme, ThemeNone, ThemeDefault)
+}
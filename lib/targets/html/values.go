@@ -0,0 +1,227 @@
+package html
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/Olian04/form-from-schema/lib"
+)
+
+// currentValue returns the value a field is rendered with: its Value, or its Default if no value is set
+func currentValue(field *lib.Field) any {
+	if field.Value != nil {
+		return field.Value
+	}
+	return field.Default
+}
+
+// inputValue returns the current value of a field formatted for an input's value attribute
+func inputValue(field *lib.Field) string {
+	switch value := currentValue(field).(type) {
+	case nil:
+		return ""
+	case float64:
+		return formatFloat(value)
+	case float32:
+		return formatFloat(float64(value))
+	case string:
+		switch field.Type {
+		case lib.FieldTypeDateTime:
+			return formatTimeValue(value, time.RFC3339, "2006-01-02T15:04")
+		case lib.FieldTypeTime:
+			return formatTimeValue(value, "15:04:05Z07:00", "15:04")
+		}
+		return value
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// formatTimeValue reformats an RFC 3339 date-time or time value into the local format
+// expected by datetime-local and time inputs, keeping seconds only when they are set.
+// Values that do not parse are returned unchanged.
+func formatTimeValue(value, layout, inputLayout string) string {
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return value
+	}
+	if t.Second() != 0 {
+		inputLayout += ":05"
+	}
+	return t.Format(inputLayout)
+}
+
+// isChecked reports whether a boolean checkbox should be rendered as checked
+func isChecked(field *lib.Field) bool {
+	switch value := currentValue(field).(type) {
+	case bool:
+		return value
+	case string:
+		return value == "true" || value == "on"
+	}
+	return false
+}
+
+// errorID returns the id of the element holding a field's error message
+func errorID(name fieldName) string {
+	return name.ID + "-error"
+}
+
+// optionValue returns the submitted string form of an option's value
+func optionValue(option lib.Option) string {
+	return fmt.Sprintf("%v", option.Value)
+}
+
+// optionID returns a stable id for the i:th option of a field
+func optionID(name fieldName, i int) string {
+	return fmt.Sprintf("%s-%d", name.ID, i)
+}
+
+// isOptionGroup reports whether the field renders as a group of radios or checkboxes
+func isOptionGroup(field *lib.Field) bool {
+	switch field.Type {
+	case lib.FieldTypeRadio:
+		return true
+	case lib.FieldTypeCheckbox:
+		return len(field.Options) > 0
+	}
+	return false
+}
+
+// isOptionSelected reports whether an option should be rendered as checked/selected.
+// The field's current value may be a single value or, for multi-value fields, a slice of values.
+func isOptionSelected(field *lib.Field, option lib.Option) bool {
+	value := currentValue(field)
+	if value == nil {
+		return false
+	}
+
+	want := optionValue(option)
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		for i := 0; i < rv.Len(); i++ {
+			if fmt.Sprintf("%v", rv.Index(i).Interface()) == want {
+				return true
+			}
+		}
+		return false
+	}
+
+	return fmt.Sprintf("%v", value) == want
+}
+
+// variantValue returns the submitted string form of a variant's value
+func variantValue(variant lib.Variant) string {
+	return fmt.Sprintf("%v", variant.Value)
+}
+
+// selectedVariant returns the index of the variant a variant field is rendered with:
+// the variant marked Selected by Populate, else the variant its current value belongs to,
+// or the first variant
+func selectedVariant(field *lib.Field) int {
+	for i, variant := range field.Variants {
+		if variant.Selected {
+			return i
+		}
+	}
+	return max(field.VariantIndex(currentValue(field)), 0)
+}
+
+// hasFieldType reports whether any of fields, or the fields nested in them, has the given type
+func hasFieldType(fields []lib.Field, fieldType lib.FieldType) bool {
+	return anyField(fields, func(field *lib.Field) bool {
+		return field.Type == fieldType
+	})
+}
+
+// hasConditional reports whether any of fields, or the fields nested in them, has a conditional
+// or is required depending on a condition
+func hasConditional(fields []lib.Field) bool {
+	return anyField(fields, func(field *lib.Field) bool {
+		return len(field.AllConditionals()) > 0 || (field.Validation != nil && field.Validation.RequiredWhen != nil)
+	})
+}
+
+// anyField reports whether match holds for any of fields or the fields nested in them
+func anyField(fields []lib.Field, match func(*lib.Field) bool) bool {
+	for i := range fields {
+		field := &fields[i]
+		if match(field) || anyField(field.Fields, match) {
+			return true
+		}
+		for _, variant := range field.Variants {
+			if anyField(variant.Fields, match) {
+				return true
+			}
+		}
+		for _, conditional := range field.AllConditionals() {
+			if anyField(conditional.Then, match) || anyField(conditional.Else, match) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// siblingValues returns the current values of a list of sibling fields, including the
+// fields of their conditionals, keyed by name
+func siblingValues(fields []lib.Field) map[string]any {
+	values := make(map[string]any)
+	for i := range fields {
+		field := &fields[i]
+		if field.Name != "" {
+			values[field.Name] = currentValue(field)
+		}
+		for _, conditional := range field.AllConditionals() {
+			for name, value := range siblingValues(conditional.Then) {
+				values[name] = value
+			}
+			for name, value := range siblingValues(conditional.Else) {
+				values[name] = value
+			}
+		}
+	}
+	return values
+}
+
+// conditionJSON returns a condition as JSON, for the conditional script to evaluate
+func conditionJSON(condition lib.Condition) string {
+	data, err := json.Marshal(condition)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// withRequiredWhen returns field marked required if its RequiredWhen condition holds for the
+// current values of its siblings, with the names of the fields the condition tests qualified
+// for the form
+func withRequiredWhen(field lib.Field, siblings []lib.Field, parent fieldName) lib.Field {
+	if field.Validation == nil || field.Validation.RequiredWhen == nil {
+		return field
+	}
+
+	field = *field.ApplyRequiredWhen(siblingValues(siblings))
+	validation := *field.Validation
+	condition := qualifyCondition(*validation.RequiredWhen, parent)
+	validation.RequiredWhen = &condition
+	field.Validation = &validation
+	return field
+}
+
+// qualifyCondition returns a copy of condition testing the qualified names of fields nested in parent
+func qualifyCondition(condition lib.Condition, parent fieldName) lib.Condition {
+	if condition.Field != "" {
+		condition.Field = parent.child(condition.Field).Name
+	}
+	if condition.Conditions != nil {
+		conditions := make([]lib.Condition, len(condition.Conditions))
+		for i, nested := range condition.Conditions {
+			conditions[i] = qualifyCondition(nested, parent)
+		}
+		condition.Conditions = conditions
+	}
+	return condition
+}
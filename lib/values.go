@@ -0,0 +1,321 @@
+package lib
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldErrors maps field paths (e.g. "address.street" or "tags[1]") to an error message.
+// Errors that do not belong to a single field are keyed by the empty path.
+type FieldErrors map[string]string
+
+// Error implements the error interface, listing every field error ordered by path
+func (e FieldErrors) Error() string {
+	paths := make([]string, 0, len(e))
+	for path := range e {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	messages := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if path == "" {
+			messages = append(messages, e[path])
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", path, e[path]))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Merge returns the errors of e along with those of other for the fields e has no error
+// for, or nil if there are none
+func (e FieldErrors) Merge(other FieldErrors) FieldErrors {
+	if len(e)+len(other) == 0 {
+		return nil
+	}
+	merged := make(FieldErrors, len(e)+len(other))
+	for path, message := range other {
+		merged[path] = message
+	}
+	for path, message := range e {
+		merged[path] = message
+	}
+	return merged
+}
+
+// ValidateValues validates submitted values, as returned by DecodeValues, against the
+// validation rules and options of the form's fields. It returns nil if all values are
+// valid, otherwise the first error found for every invalid field.
+// Patterns match anywhere in the value, as in JSON Schema, unless anchored with ^ and $;
+// patterns that are not valid Go regular expressions are not enforced.
+func (f *Form) ValidateValues(values map[string]any) FieldErrors {
+	return f.ValidateValuesWithVariants(values, nil)
+}
+
+// ValidateValuesWithVariants validates submitted values like ValidateValues, validating the
+// value of each variant field against the variant selected for it, as returned by
+// DecodeVariants, rather than the variant the value belongs to
+func (f *Form) ValidateValuesWithVariants(values map[string]any, selected SelectedVariants) FieldErrors {
+	if f == nil {
+		return FieldErrors{"": "form cannot be nil"}
+	}
+
+	errs := FieldErrors{}
+	validateFieldValues(f.Fields, values, "", selected, errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// validateFieldValues validates the values of a list of sibling fields
+func validateFieldValues(fields []Field, values map[string]any, path string, selected SelectedVariants, errs FieldErrors) {
+	for i := range fields {
+		field := &fields[i]
+		if field.Name != "" {
+			validateFieldValue(field.ApplyRequiredWhen(values), values[field.Name], joinPath(path, field.Name), selected, errs)
+		}
+
+		for _, conditional := range field.AllConditionals() {
+			branch := conditional.Else
+			if conditional.Met(values) {
+				branch = conditional.Then
+			}
+			validateFieldValues(branch, values, path, selected, errs)
+		}
+	}
+}
+
+// validateFieldValue validates a single value against its field, recording the first error
+func validateFieldValue(field *Field, value any, path string, selected SelectedVariants, errs FieldErrors) {
+	validation := field.Validation
+	if validation == nil {
+		validation = &Validation{}
+	}
+
+	if isEmptyValue(value) {
+		if validation.Required && supportsRequiredValue(field) {
+			errs[path] = "This field is required"
+		}
+		return
+	}
+
+	switch field.Type {
+	case FieldTypeObject:
+		nested, ok := value.(map[string]any)
+		if !ok {
+			errs[path] = "Must be an object"
+			return
+		}
+		validateFieldValues(field.Fields, nested, path, selected, errs)
+		return
+
+	case FieldTypeVariant:
+		validateVariantValue(field, value, path, selected, errs)
+		return
+
+	case FieldTypeArray:
+		items, ok := toSlice(value)
+		if !ok {
+			errs[path] = "Must be a list"
+			return
+		}
+		if message := validateItemCount(validation, len(items)); message != "" {
+			errs[path] = message
+			return
+		}
+		if len(field.Fields) == 1 {
+			for i, item := range items {
+				validateFieldValue(&field.Fields[0], item, fmt.Sprintf("%s[%d]", path, i), selected, errs)
+			}
+		}
+		return
+	}
+
+	if field.Type == FieldTypeCheckbox && len(field.Options) > 0 {
+		items, ok := toSlice(value)
+		if !ok {
+			items = []any{value}
+		}
+		for _, item := range items {
+			if !hasOption(field, item) {
+				errs[path] = "Must be one of the allowed options"
+				return
+			}
+		}
+		return
+	}
+
+	if len(field.Options) > 0 {
+		if !hasOption(field, value) {
+			errs[path] = "Must be one of the allowed options"
+		}
+		return
+	}
+
+	if message := validateScalar(field, validation, value); message != "" {
+		errs[path] = message
+	}
+}
+
+// validateVariantValue validates a value against the variant of the field selected for it,
+// or else the one it belongs to
+func validateVariantValue(field *Field, value any, path string, selected SelectedVariants, errs FieldErrors) {
+	index := field.selectedVariant(value, path, selected)
+	if index < 0 {
+		errs[path] = "Must match one of the options"
+		return
+	}
+	variant := &field.Variants[index]
+
+	if variant.Scalar {
+		validateFieldValue(&variant.Fields[0], value, path, selected, errs)
+		return
+	}
+	nested, _ := value.(map[string]any)
+	validateFieldValues(variant.Fields, nested, path, selected, errs)
+}
+
+// validateScalar validates a single non-empty scalar value, returning an error message or ""
+func validateScalar(field *Field, validation *Validation, value any) string {
+	switch dataTypeOf(field) {
+	case DataTypeNumber, DataTypeInteger:
+		number, ok := toFloat(value)
+		if !ok {
+			return "Must be a number"
+		}
+		if dataTypeOf(field) == DataTypeInteger && number != math.Trunc(number) {
+			return "Must be a whole number"
+		}
+		if validation.Min != nil && validation.ExclusiveMin && number <= *validation.Min {
+			return fmt.Sprintf("Must be greater than %v", *validation.Min)
+		}
+		if validation.Min != nil && number < *validation.Min {
+			return fmt.Sprintf("Must be at least %v", *validation.Min)
+		}
+		if validation.Max != nil && validation.ExclusiveMax && number >= *validation.Max {
+			return fmt.Sprintf("Must be less than %v", *validation.Max)
+		}
+		if validation.Max != nil && number > *validation.Max {
+			return fmt.Sprintf("Must be at most %v", *validation.Max)
+		}
+		if validation.Step != nil && !isMultiple(number, *validation.Step) {
+			return fmt.Sprintf("Must be a multiple of %v", *validation.Step)
+		}
+
+	case DataTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return "Must be true or false"
+		}
+
+	case DataTypeString:
+		text, ok := value.(string)
+		if !ok {
+			return "Must be text"
+		}
+		length := utf8.RuneCountInString(text)
+		if validation.MinLength != nil && length < *validation.MinLength {
+			return fmt.Sprintf("Must be at least %d characters long", *validation.MinLength)
+		}
+		if validation.MaxLength != nil && length > *validation.MaxLength {
+			return fmt.Sprintf("Must be at most %d characters long", *validation.MaxLength)
+		}
+		if validation.Pattern != "" {
+			if pattern, err := regexp.Compile(validation.Pattern); err == nil && !pattern.MatchString(text) {
+				if validation.PatternError != "" {
+					return validation.PatternError
+				}
+				return "Invalid format"
+			}
+		}
+	}
+
+	return ""
+}
+
+// validateItemCount validates the number of items of an array, returning an error message or ""
+func validateItemCount(validation *Validation, count int) string {
+	if validation.MinItems != nil && count < *validation.MinItems {
+		return fmt.Sprintf("Must have at least %d items", *validation.MinItems)
+	}
+	if validation.MaxItems != nil && count > *validation.MaxItems {
+		return fmt.Sprintf("Must have at most %d items", *validation.MaxItems)
+	}
+	return ""
+}
+
+// supportsRequiredValue reports whether a required rule can fail for the field.
+// A boolean checkbox always has a value: unchecked means false.
+func supportsRequiredValue(field *Field) bool {
+	return !(field.Type == FieldTypeCheckbox && len(field.Options) == 0)
+}
+
+// hasOption reports whether value is one of the field's option values
+func hasOption(field *Field, value any) bool {
+	want := fmt.Sprintf("%v", value)
+	for _, option := range field.Options {
+		if fmt.Sprintf("%v", option.Value) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// isMultiple reports whether number is a multiple of step, counted from 0 as JSON Schema's
+// multipleOf is
+func isMultiple(number, step float64) bool {
+	quotient := number / step
+	return math.Abs(quotient-math.Round(quotient)) < 1e-9
+}
+
+// isEmptyValue reports whether a value counts as not submitted
+func isEmptyValue(value any) bool {
+	if value == nil {
+		return true
+	}
+	if text, ok := value.(string); ok {
+		return text == ""
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	}
+	return false
+}
+
+// toSlice converts a slice of any element type to []any
+func toSlice(value any) ([]any, bool) {
+	if items, ok := value.([]any); ok {
+		return items, true
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	items := make([]any, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, true
+}
+
+// toFloat converts any Go number to a float64
+func toFloat(value any) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,367 @@
+package lib
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestForm_ValidateValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []Field
+		values map[string]any
+		want   FieldErrors
+	}{
+		{
+			name:   "valid values",
+			fields: []Field{{Name: "username", Type: FieldTypeText, Validation: &Validation{Required: true, MinLength: intPtr(3)}}},
+			values: map[string]any{"username": "alice"},
+			want:   nil,
+		},
+		{
+			name:   "missing required value",
+			fields: []Field{{Name: "username", Type: FieldTypeText, Validation: &Validation{Required: true}}},
+			values: map[string]any{},
+			want:   FieldErrors{"username": "This field is required"},
+		},
+		{
+			name:   "empty string is missing",
+			fields: []Field{{Name: "username", Type: FieldTypeText, Validation: &Validation{Required: true}}},
+			values: map[string]any{"username": ""},
+			want:   FieldErrors{"username": "This field is required"},
+		},
+		{
+			name:   "optional empty value skips other rules",
+			fields: []Field{{Name: "nickname", Type: FieldTypeText, Validation: &Validation{MinLength: intPtr(3)}}},
+			values: map[string]any{},
+			want:   nil,
+		},
+		{
+			name:   "required boolean checkbox accepts false",
+			fields: []Field{{Name: "subscribe", Type: FieldTypeCheckbox, Validation: &Validation{Required: true}}},
+			values: map[string]any{"subscribe": false},
+			want:   nil,
+		},
+		{
+			name: "string lengths count characters",
+			fields: []Field{
+				{Name: "short", Type: FieldTypeText, Validation: &Validation{MinLength: intPtr(3)}},
+				{Name: "long", Type: FieldTypeText, Validation: &Validation{MaxLength: intPtr(3)}},
+				{Name: "unicode", Type: FieldTypeText, Validation: &Validation{MaxLength: intPtr(3)}},
+			},
+			values: map[string]any{"short": "ab", "long": "abcd", "unicode": "åäö"},
+			want: FieldErrors{
+				"short": "Must be at least 3 characters long",
+				"long":  "Must be at most 3 characters long",
+			},
+		},
+		{
+			name: "pattern matches anywhere unless anchored",
+			fields: []Field{
+				{Name: "code", Type: FieldTypeText, Validation: &Validation{Pattern: "^[0-9]+$"}},
+				{Name: "zip", Type: FieldTypeText, Validation: &Validation{Pattern: "[0-9]{5}", PatternError: "Enter five digits"}},
+				{Name: "ok", Type: FieldTypeText, Validation: &Validation{Pattern: "[a-z]+"}},
+			},
+			values: map[string]any{"code": "12a", "zip": "1234", "ok": "ABC def"},
+			want: FieldErrors{
+				"code": "Invalid format",
+				"zip":  "Enter five digits",
+			},
+		},
+		{
+			name:   "unsupported pattern is not enforced",
+			fields: []Field{{Name: "code", Type: FieldTypeText, Validation: &Validation{Pattern: "(?=a)a"}}},
+			values: map[string]any{"code": "b"},
+			want:   nil,
+		},
+		{
+			name: "number range and step",
+			fields: []Field{
+				{Name: "low", Type: FieldTypeNumber, Validation: &Validation{Min: floatPtr(18)}},
+				{Name: "high", Type: FieldTypeNumber, Validation: &Validation{Max: floatPtr(120)}},
+				{Name: "step", Type: FieldTypeNumber, Validation: &Validation{Step: floatPtr(0.5)}},
+				{Name: "offset", Type: FieldTypeNumber, Validation: &Validation{Min: floatPtr(3), Step: floatPtr(5)}},
+				{Name: "zero_based", Type: FieldTypeNumber, Validation: &Validation{Min: floatPtr(3), Step: floatPtr(5)}},
+				{Name: "fine", Type: FieldTypeNumber, Validation: &Validation{Step: floatPtr(0.1)}},
+			},
+			values: map[string]any{"low": int64(17), "high": 121.0, "step": 0.7, "offset": int64(8), "zero_based": int64(5), "fine": 0.3},
+			want: FieldErrors{
+				"low":    "Must be at least 18",
+				"high":   "Must be at most 120",
+				"step":   "Must be a multiple of 0.5",
+				"offset": "Must be a multiple of 5",
+			},
+		},
+		{
+			name: "exclusive range",
+			fields: []Field{
+				{Name: "low", Type: FieldTypeNumber, Validation: &Validation{Min: floatPtr(0), ExclusiveMin: true}},
+				{Name: "high", Type: FieldTypeNumber, Validation: &Validation{Max: floatPtr(1), ExclusiveMax: true}},
+				{Name: "within", Type: FieldTypeNumber, Validation: &Validation{Min: floatPtr(0), ExclusiveMin: true, Max: floatPtr(1), ExclusiveMax: true}},
+			},
+			values: map[string]any{"low": int64(0), "high": 1.0, "within": 0.5},
+			want: FieldErrors{
+				"low":  "Must be greater than 0",
+				"high": "Must be less than 1",
+			},
+		},
+		{
+			name: "type mismatches",
+			fields: []Field{
+				{Name: "age", Type: FieldTypeNumber},
+				{Name: "count", Type: FieldTypeNumber, DataType: DataTypeInteger},
+				{Name: "name", Type: FieldTypeText},
+			},
+			values: map[string]any{"age": "old", "count": 1.5, "name": 3},
+			want: FieldErrors{
+				"age":   "Must be a number",
+				"count": "Must be a whole number",
+				"name":  "Must be text",
+			},
+		},
+		{
+			name: "option membership",
+			fields: []Field{
+				{Name: "size", Type: FieldTypeSelect, Options: []Option{{Label: "S", Value: "s"}}},
+				{Name: "rating", Type: FieldTypeRadio, Options: []Option{{Label: "1", Value: 1.0}}},
+				{Name: "tags", Type: FieldTypeCheckbox, Options: []Option{{Label: "Go", Value: "go"}}},
+			},
+			values: map[string]any{"size": "xl", "rating": 1.0, "tags": []any{"go", "cobol"}},
+			want: FieldErrors{
+				"size": "Must be one of the allowed options",
+				"tags": "Must be one of the allowed options",
+			},
+		},
+		{
+			name: "array item count and items",
+			fields: []Field{
+				{Name: "tags", Type: FieldTypeArray, Validation: &Validation{MaxItems: intPtr(2)},
+					Fields: []Field{{Name: "item", Type: FieldTypeText}}},
+				{Name: "scores", Type: FieldTypeArray, Validation: &Validation{MinItems: intPtr(1)},
+					Fields: []Field{{Name: "item", Type: FieldTypeNumber, Validation: &Validation{Max: floatPtr(10)}}}},
+			},
+			values: map[string]any{"tags": []any{"a", "b", "c"}, "scores": []any{int64(5), int64(11)}},
+			want: FieldErrors{
+				"tags":      "Must have at most 2 items",
+				"scores[1]": "Must be at most 10",
+			},
+		},
+		{
+			name: "nested objects",
+			fields: []Field{
+				{Name: "address", Type: FieldTypeObject, Fields: []Field{
+					{Name: "street", Type: FieldTypeText, Validation: &Validation{Required: true}},
+					{Name: "zip", Type: FieldTypeText, Validation: &Validation{Pattern: "[0-9]{5}"}},
+				}},
+			},
+			values: map[string]any{"address": map[string]any{"zip": "abc"}},
+			want: FieldErrors{
+				"address.street": "This field is required",
+				"address.zip":    "Invalid format",
+			},
+		},
+		{
+			name: "array of objects",
+			fields: []Field{
+				{Name: "lines", Type: FieldTypeArray, Fields: []Field{
+					{Name: "item", Type: FieldTypeObject, Fields: []Field{
+						{Name: "qty", Type: FieldTypeNumber, Validation: &Validation{Required: true, Min: floatPtr(1)}},
+					}},
+				}},
+			},
+			values: map[string]any{"lines": []any{map[string]any{"qty": int64(0)}, map[string]any{"sku": "x"}}},
+			want: FieldErrors{
+				"lines[0].qty": "Must be at least 1",
+				"lines[1].qty": "This field is required",
+			},
+		},
+		{
+			name: "conditional branch follows condition",
+			fields: []Field{
+				{Name: "has_company", Type: FieldTypeCheckbox, Conditional: &ConditionalField{
+					Condition: "has_company",
+					Then:      []Field{{Name: "company", Type: FieldTypeText, Validation: &Validation{Required: true}}},
+					Else:      []Field{{Name: "reason", Type: FieldTypeText, Validation: &Validation{Required: true}}},
+				}},
+			},
+			values: map[string]any{"has_company": true},
+			want:   FieldErrors{"company": "This field is required"},
+		},
+		{
+			name: "conditional else branch",
+			fields: []Field{
+				{Name: "country", Type: FieldTypeText, Conditional: &ConditionalField{
+					Condition: "country",
+					Value:     "US",
+					Then:      []Field{{Name: "state", Type: FieldTypeText, Validation: &Validation{Required: true}}},
+					Else:      []Field{{Name: "region", Type: FieldTypeText, Validation: &Validation{Required: true}}},
+				}},
+			},
+			values: map[string]any{"country": "SE"},
+			want:   FieldErrors{"region": "This field is required"},
+		},
+		{
+			name: "conditional with compound condition",
+			fields: []Field{
+				{Name: "age", Type: FieldTypeNumber, DataType: DataTypeInteger},
+				{Name: "country", Type: FieldTypeText, Conditional: &ConditionalField{
+					When: &Condition{Operator: ConditionAnd, Conditions: []Condition{
+						{Operator: ConditionRange, Field: "age", Min: floatPtr(18)},
+						{Operator: ConditionIn, Field: "country", Values: []any{"SE", "NO"}},
+					}},
+					Then: []Field{{Name: "license", Type: FieldTypeText, Validation: &Validation{Required: true}}},
+				}},
+			},
+			values: map[string]any{"age": int64(20), "country": "NO"},
+			want:   FieldErrors{"license": "This field is required"},
+		},
+		{
+			name: "further conditionals",
+			fields: []Field{
+				{Name: "country", Type: FieldTypeText,
+					Conditional: &ConditionalField{
+						When: &Condition{Operator: ConditionEquals, Field: "country", Value: "US"},
+						Then: []Field{{Name: "state", Type: FieldTypeText}},
+					},
+					Conditionals: []ConditionalField{{
+						When: &Condition{Operator: ConditionPresent, Field: "country"},
+						Then: []Field{{Name: "vat", Type: FieldTypeText, Validation: &Validation{Required: true}}},
+					}},
+				},
+			},
+			values: map[string]any{"country": "SE"},
+			want:   FieldErrors{"vat": "This field is required"},
+		},
+		{
+			name: "required when condition holds",
+			fields: []Field{
+				{Name: "billing_address", Type: FieldTypeText, Validation: &Validation{
+					RequiredWhen: &Condition{Operator: ConditionPresent, Field: "credit_card"},
+				}},
+				{Name: "credit_card", Type: FieldTypeText},
+			},
+			values: map[string]any{"credit_card": "4111"},
+			want:   FieldErrors{"billing_address": "This field is required"},
+		},
+		{
+			name: "not required when condition does not hold",
+			fields: []Field{
+				{Name: "billing_address", Type: FieldTypeText, Validation: &Validation{
+					RequiredWhen: &Condition{Operator: ConditionPresent, Field: "credit_card"},
+				}},
+				{Name: "credit_card", Type: FieldTypeText},
+			},
+			values: map[string]any{},
+			want:   nil,
+		},
+		{
+			name:   "variant fields are validated",
+			fields: []Field{paymentField()},
+			values: map[string]any{"payment": map[string]any{"method": "card", "number": "1234"}},
+			want:   FieldErrors{"payment.number": "Invalid format"},
+		},
+		{
+			name:   "only the selected variant is validated",
+			fields: []Field{paymentField()},
+			values: map[string]any{"payment": map[string]any{"method": "bank", "iban": "SE35"}},
+			want:   nil,
+		},
+		{
+			name:   "unknown variant",
+			fields: []Field{paymentField()},
+			values: map[string]any{"payment": map[string]any{"method": "cash"}},
+			want:   FieldErrors{"payment": "Must match one of the options"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &Form{Fields: tt.fields}
+			got := form.ValidateValues(tt.values)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Form.ValidateValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForm_ValidateValues_NilForm(t *testing.T) {
+	var form *Form
+	errs := form.ValidateValues(map[string]any{})
+	if errs[""] != "form cannot be nil" {
+		t.Errorf("Form.ValidateValues() on nil form = %v, want form error", errs)
+	}
+}
+
+func TestForm_ValidateValues_Decoded(t *testing.T) {
+	form := &Form{
+		Fields: []Field{
+			{Name: "username", Type: FieldTypeText, Validation: &Validation{Required: true, MinLength: intPtr(3)}},
+			{Name: "age", Type: FieldTypeNumber, DataType: DataTypeInteger, Validation: &Validation{Min: floatPtr(18)}},
+		},
+	}
+
+	values, err := form.DecodeValues(url.Values{"username": {"al"}, "age": {"16"}})
+	if err != nil {
+		t.Fatalf("Form.DecodeValues() error = %v", err)
+	}
+
+	errs := form.ValidateValues(values)
+	want := "age: Must be at least 18; username: Must be at least 3 characters long"
+	if errs == nil || errs.Error() != want {
+		t.Errorf("Form.ValidateValues() error = %v, want %q", errs, want)
+	}
+}
+
+func TestForm_ValidateValuesWithVariants(t *testing.T) {
+	form := &Form{Fields: []Field{{Name: "code", Type: FieldTypeVariant, Variants: []Variant{
+		{Label: "Short", Value: 0, Scalar: true, Fields: []Field{{Name: "value", Type: FieldTypeText, Validation: &Validation{MaxLength: intPtr(3)}}}},
+		{Label: "Long", Value: 1, Scalar: true, Fields: []Field{{Name: "value", Type: FieldTypeText, Validation: &Validation{MinLength: intPtr(10)}}}},
+	}}}}
+	values := map[string]any{"code": "abcd"}
+
+	tests := []struct {
+		name     string
+		selected SelectedVariants
+		want     FieldErrors
+	}{
+		{name: "no selection", selected: nil, want: FieldErrors{"code": "Must match one of the options"}},
+		{name: "selected variant", selected: SelectedVariants{"code": 1}, want: FieldErrors{"code": "Must be at least 10 characters long"}},
+		{name: "selection out of range", selected: SelectedVariants{"code": 2}, want: FieldErrors{"code": "Must match one of the options"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := form.ValidateValuesWithVariants(values, tt.selected); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Form.ValidateValuesWithVariants() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldErrors_Merge(t *testing.T) {
+	tests := []struct {
+		name  string
+		errs  FieldErrors
+		other FieldErrors
+		want  FieldErrors
+	}{
+		{name: "both empty", want: nil},
+		{name: "only other", other: FieldErrors{"age": "Must be at least 18"}, want: FieldErrors{"age": "Must be at least 18"}},
+		{
+			name:  "first error of a field wins",
+			errs:  FieldErrors{"age": "Must be a whole number"},
+			other: FieldErrors{"age": "Must be a number", "name": "This field is required"},
+			want:  FieldErrors{"age": "Must be a whole number", "name": "This field is required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.errs.Merge(tt.other); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FieldErrors.Merge() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
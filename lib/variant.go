@@ -0,0 +1,79 @@
+package lib
+
+import "fmt"
+
+// SelectedVariants holds the index of the variant submitted for each variant field, keyed by
+// field path like FieldErrors. A value alone may not tell its variant, for instance when it
+// is invalid or the field has no discriminator.
+type SelectedVariants map[string]int
+
+// VariantIndex returns the index of the variant of a variant field that a value belongs to,
+// or -1 if none matches. Objects are matched by their discriminator property if the field
+// has one; otherwise the first variant whose fields accept the value is chosen.
+func (field *Field) VariantIndex(value any) int {
+	if value == nil || len(field.Variants) == 0 {
+		return -1
+	}
+
+	object, isObject := value.(map[string]any)
+	if isObject && field.Discriminator != "" {
+		if discriminator, ok := object[field.Discriminator]; ok {
+			return field.variantIndexByValue(fmt.Sprintf("%v", discriminator))
+		}
+	}
+
+	for i := range field.Variants {
+		variant := &field.Variants[i]
+		errs := FieldErrors{}
+		if variant.Scalar {
+			if isObject {
+				continue
+			}
+			validateFieldValue(&variant.Fields[0], value, "", nil, errs)
+		} else {
+			if !isObject || !hasOnlyFields(object, variant.Fields, field.Discriminator) {
+				continue
+			}
+			validateFieldValues(variant.Fields, object, "", nil, errs)
+		}
+		if len(errs) == 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// selectedVariant returns the index of the variant submitted for the variant field at path
+// if selected records one, otherwise the index of the variant value belongs to
+func (field *Field) selectedVariant(value any, path string, selected SelectedVariants) int {
+	if index, ok := selected[path]; ok && index >= 0 && index < len(field.Variants) {
+		return index
+	}
+	return field.VariantIndex(value)
+}
+
+// variantIndexByValue returns the index of the variant with the given submitted value, or -1
+func (field *Field) variantIndexByValue(value string) int {
+	for i, variant := range field.Variants {
+		if fmt.Sprintf("%v", variant.Value) == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasOnlyFields reports whether every property of object, other than the discriminator,
+// belongs to one of fields
+func hasOnlyFields(object map[string]any, fields []Field, discriminator string) bool {
+	names := make(map[string]bool)
+	for _, name := range nestedFieldNames(fields) {
+		names[name] = true
+	}
+	for name := range object {
+		if name != discriminator && !names[name] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package lib
+
+import (
+	"testing"
+)
+
+// paymentField returns a variant field with a discriminator and a scalar variant
+func paymentField() Field {
+	return Field{
+		Name:          "payment",
+		Type:          FieldTypeVariant,
+		Discriminator: "method",
+		Variants: []Variant{
+			{Label: "Card", Value: "card", Fields: []Field{
+				{Name: "number", Type: FieldTypeText, Validation: &Validation{Required: true, Pattern: "[0-9]{16}"}},
+			}},
+			{Label: "Bank transfer", Value: "bank", Fields: []Field{
+				{Name: "iban", Type: FieldTypeText, Validation: &Validation{Required: true}},
+				{Name: "bic", Type: FieldTypeText},
+			}},
+		},
+	}
+}
+
+func TestField_VariantIndex(t *testing.T) {
+	undiscriminated := Field{
+		Name: "contact",
+		Type: FieldTypeVariant,
+		Variants: []Variant{
+			{Label: "Email", Value: 0, Fields: []Field{{Name: "email", Type: FieldTypeEmail}}},
+			{Label: "Phone", Value: 1, Fields: []Field{{Name: "phone", Type: FieldTypeText}}},
+			{Label: "Count", Value: 2, Scalar: true, Fields: []Field{{Name: "value", Type: FieldTypeNumber, DataType: DataTypeInteger}}},
+		},
+	}
+	payment := paymentField()
+
+	tests := []struct {
+		name  string
+		field Field
+		value any
+		want  int
+	}{
+		{name: "no value", field: payment, value: nil, want: -1},
+		{name: "by discriminator", field: payment, value: map[string]any{"method": "bank"}, want: 1},
+		{name: "unknown discriminator", field: payment, value: map[string]any{"method": "cash"}, want: -1},
+		{name: "by properties", field: undiscriminated, value: map[string]any{"phone": "555"}, want: 1},
+		{name: "scalar value", field: undiscriminated, value: int64(3), want: 2},
+		{name: "no matching variant", field: undiscriminated, value: map[string]any{"fax": "555"}, want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.field.VariantIndex(tt.value); got != tt.want {
+				t.Errorf("Field.VariantIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}